@@ -1,11 +1,30 @@
 package cmd
 
 import (
+	"errors"
+	"fmt"
 	"os"
 	"os/user"
 	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+	"github.com/dhth/prs/ui"
+)
+
+var (
+	errIncorrectSourceProvided = errors.New("incorrect source provided")
+	errDuplicateRepoProvided   = errors.New("repo provided more than once")
+	errIncorrectQueryProvided  = errors.New("incorrect query provided")
+	errIncorrectNotifications  = errors.New("incorrect notifications config provided")
 )
 
+var notificationEvents = map[string]ui.NotificationEvent{
+	"approved":          ui.PRApproved,
+	"changes-requested": ui.PRChangesRequested,
+	"checks-failed":     ui.PRChecksFailed,
+	"merged":            ui.PRMerged,
+}
+
 func expandTilde(path string) string {
 	if strings.HasPrefix(path, "~") {
 		usr, err := user.Current()
@@ -16,3 +35,164 @@ func expandTilde(path string) string {
 	}
 	return path
 }
+
+// parseRepo parses a repo provided as owner/repo or host/owner/repo. The host
+// is left empty if it's the same as defaultHost.
+func parseRepo(r string, defaultHost string) (ui.Repo, error) {
+	repoEls := strings.Split(r, "/")
+	// TODO: there can be more validations done here, maybe regex based
+	var repoHost string
+	switch len(repoEls) {
+	case 2:
+	case 3:
+		repoHost = auth.NormalizeHostname(strings.TrimSpace(repoEls[0]))
+		repoEls = repoEls[1:]
+	default:
+		return ui.Repo{}, fmt.Errorf("%w: %s", errIncorrectRepoProvided, r)
+	}
+
+	if repoHost == defaultHost {
+		repoHost = ""
+	}
+
+	owner := strings.TrimSpace(repoEls[0])
+	name := strings.TrimSpace(repoEls[1])
+	if owner == "" || name == "" {
+		return ui.Repo{}, fmt.Errorf("%w: %s", errIncorrectRepoProvided, r)
+	}
+
+	return ui.Repo{
+		Host:  repoHost,
+		Owner: owner,
+		Name:  name,
+	}, nil
+}
+
+// getReposFromSources flattens the grouped "sources" config into a list of
+// repos, validating it along the way.
+func getReposFromSources(sources []ui.OwnerSource, defaultHost string) ([]ui.Repo, error) {
+	var repos []ui.Repo
+	for i, src := range sources {
+		owner := strings.TrimSpace(src.Owner)
+		if owner == "" || strings.Contains(owner, "/") {
+			return nil, fmt.Errorf("%w: source #%d has an invalid owner: %q", errIncorrectSourceProvided, i+1, src.Owner)
+		}
+
+		if len(src.Repos) == 0 {
+			return nil, fmt.Errorf("%w: no repos provided for owner %q", errIncorrectSourceProvided, owner)
+		}
+
+		var host string
+		if src.Host != "" {
+			host = auth.NormalizeHostname(strings.TrimSpace(src.Host))
+			if host == defaultHost {
+				host = ""
+			}
+		}
+
+		for _, r := range src.Repos {
+			name := strings.TrimSpace(r.Name)
+			if name == "" || strings.Contains(name, "/") {
+				return nil, fmt.Errorf("%w: owner %q has an invalid repo name: %q", errIncorrectSourceProvided, owner, r.Name)
+			}
+
+			repos = append(repos, ui.Repo{
+				Host:  host,
+				Owner: owner,
+				Name:  name,
+			})
+		}
+	}
+
+	return repos, nil
+}
+
+func checkForDuplicateRepos(repos []ui.Repo) error {
+	seen := make(map[ui.Repo]struct{}, len(repos))
+	for _, r := range repos {
+		if _, ok := seen[r]; ok {
+			if r.Host != "" {
+				return fmt.Errorf("%w: %s/%s/%s", errDuplicateRepoProvided, r.Host, r.Owner, r.Name)
+			}
+			return fmt.Errorf("%w: %s/%s", errDuplicateRepoProvided, r.Owner, r.Name)
+		}
+		seen[r] = struct{}{}
+	}
+	return nil
+}
+
+// getNamedQueries validates the named queries from the config file. Results
+// are matched to tabs by query, so queries can't be repeated either.
+func getNamedQueries(queries []ui.NamedQuery) ([]ui.NamedQuery, error) {
+	if len(queries) == 0 {
+		return nil, fmt.Errorf("%w: no queries provided", errIncorrectQueryProvided)
+	}
+
+	names := make(map[string]struct{}, len(queries))
+	queryStrs := make(map[string]string, len(queries))
+	namedQueries := make([]ui.NamedQuery, len(queries))
+	for i, q := range queries {
+		name := strings.TrimSpace(q.Name)
+		query := strings.TrimSpace(q.Query)
+		if name == "" {
+			return nil, fmt.Errorf("%w: query #%d has no name", errIncorrectQueryProvided, i+1)
+		}
+		if query == "" {
+			return nil, fmt.Errorf("%w: query %q is empty", errIncorrectQueryProvided, name)
+		}
+		if _, ok := names[name]; ok {
+			return nil, fmt.Errorf("%w: name %q is used more than once", errIncorrectQueryProvided, name)
+		}
+		names[name] = struct{}{}
+		if other, ok := queryStrs[query]; ok {
+			return nil, fmt.Errorf("%w: queries %q and %q are the same", errIncorrectQueryProvided, other, name)
+		}
+		queryStrs[query] = name
+
+		namedQueries[i] = ui.NamedQuery{Name: name, Query: query}
+	}
+
+	return namedQueries, nil
+}
+
+func getNotifications(cfg ui.NotificationsConfig) (*ui.Notifications, error) {
+	var method ui.NotificationMethod
+	switch strings.TrimSpace(cfg.Via) {
+	case "":
+		method = ui.AutoNotification
+	case "notify-send":
+		method = ui.NotifySendNotification
+	case "osc9":
+		method = ui.OSC9Notification
+	default:
+		return nil, fmt.Errorf("%w: unknown method %q; values: notify-send, osc9", errIncorrectNotifications, cfg.Via)
+	}
+
+	if len(cfg.Rules) == 0 {
+		return nil, fmt.Errorf("%w: no rules provided", errIncorrectNotifications)
+	}
+
+	rules := make([]ui.NotificationRule, len(cfg.Rules))
+	for i, r := range cfg.Rules {
+		if len(r.Events) == 0 {
+			return nil, fmt.Errorf("%w: rule #%d has no events", errIncorrectNotifications, i+1)
+		}
+
+		events := make([]ui.NotificationEvent, len(r.Events))
+		for j, e := range r.Events {
+			event, ok := notificationEvents[strings.TrimSpace(e)]
+			if !ok {
+				return nil, fmt.Errorf("%w: unknown event %q in rule #%d; values: approved, changes-requested, checks-failed, merged", errIncorrectNotifications, e, i+1)
+			}
+			events[j] = event
+		}
+
+		rules[i] = ui.NotificationRule{
+			Events:  events,
+			Repos:   r.Repos,
+			Authors: r.Authors,
+		}
+	}
+
+	return &ui.Notifications{Method: method, Rules: rules}, nil
+}
@@ -0,0 +1,145 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/dhth/prs/ui"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetReposFromSources(t *testing.T) {
+	sources := []ui.OwnerSource{
+		{Owner: "dhth", Repos: []struct {
+			Name string `yaml:"name" mapstructure:"name"`
+		}{{Name: "prs"}, {Name: "omm"}}},
+		{Owner: "team", Host: "GHES.example.com", Repos: []struct {
+			Name string `yaml:"name" mapstructure:"name"`
+		}{{Name: "service"}}},
+		{Owner: "other", Host: "github.com", Repos: []struct {
+			Name string `yaml:"name" mapstructure:"name"`
+		}{{Name: "repo"}}},
+	}
+
+	repos, err := getReposFromSources(sources, "github.com")
+	require.NoError(t, err)
+	assert.Equal(t, []ui.Repo{
+		{Owner: "dhth", Name: "prs"},
+		{Owner: "dhth", Name: "omm"},
+		{Host: "ghes.example.com", Owner: "team", Name: "service"},
+		{Owner: "other", Name: "repo"},
+	}, repos)
+}
+
+func TestGetReposFromSourcesValidation(t *testing.T) {
+	testCases := []struct {
+		name    string
+		sources []ui.OwnerSource
+	}{
+		{
+			name:    "empty owner",
+			sources: []ui.OwnerSource{{Owner: " "}},
+		},
+		{
+			name:    "no repos",
+			sources: []ui.OwnerSource{{Owner: "dhth"}},
+		},
+		{
+			name: "invalid repo name",
+			sources: []ui.OwnerSource{{Owner: "dhth", Repos: []struct {
+				Name string `yaml:"name" mapstructure:"name"`
+			}{{Name: "dhth/prs"}}}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := getReposFromSources(tt.sources, "github.com")
+			assert.ErrorIs(t, err, errIncorrectSourceProvided)
+		})
+	}
+}
+
+func TestCheckForDuplicateRepos(t *testing.T) {
+	repos := []ui.Repo{{Owner: "dhth", Name: "prs"}, {Owner: "dhth", Name: "omm"}}
+	require.NoError(t, checkForDuplicateRepos(repos))
+
+	repos = append(repos, ui.Repo{Owner: "dhth", Name: "prs"})
+	assert.ErrorIs(t, checkForDuplicateRepos(repos), errDuplicateRepoProvided)
+}
+
+func TestGetNamedQueries(t *testing.T) {
+	queries, err := getNamedQueries([]ui.NamedQuery{
+		{Name: " mine ", Query: "type:pr author:@me"},
+		{Name: "needs my review", Query: "type:pr review-requested:@me"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []ui.NamedQuery{
+		{Name: "mine", Query: "type:pr author:@me"},
+		{Name: "needs my review", Query: "type:pr review-requested:@me"},
+	}, queries)
+
+	_, err = getNamedQueries([]ui.NamedQuery{
+		{Name: "mine", Query: "type:pr author:@me"},
+		{Name: "mine", Query: "type:pr assignee:@me"},
+	})
+	assert.ErrorIs(t, err, errIncorrectQueryProvided)
+
+	_, err = getNamedQueries([]ui.NamedQuery{
+		{Name: "mine", Query: "type:pr author:@me"},
+		{Name: "also mine", Query: " type:pr author:@me"},
+	})
+	assert.ErrorIs(t, err, errIncorrectQueryProvided)
+
+	_, err = getNamedQueries([]ui.NamedQuery{{Name: "mine"}})
+	assert.ErrorIs(t, err, errIncorrectQueryProvided)
+}
+
+func TestGetNotifications(t *testing.T) {
+	notifications, err := getNotifications(ui.NotificationsConfig{
+		Via: "osc9",
+		Rules: []ui.NotificationRuleConfig{
+			{Events: []string{"approved", " merged"}, Repos: []string{"dhth/prs"}},
+			{Events: []string{"checks-failed"}},
+		},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &ui.Notifications{
+		Method: ui.OSC9Notification,
+		Rules: []ui.NotificationRule{
+			{Events: []ui.NotificationEvent{ui.PRApproved, ui.PRMerged}, Repos: []string{"dhth/prs"}},
+			{Events: []ui.NotificationEvent{ui.PRChecksFailed}},
+		},
+	}, notifications)
+}
+
+func TestGetNotificationsValidation(t *testing.T) {
+	testCases := []struct {
+		name string
+		cfg  ui.NotificationsConfig
+	}{
+		{
+			name: "unknown method",
+			cfg:  ui.NotificationsConfig{Via: "email", Rules: []ui.NotificationRuleConfig{{Events: []string{"merged"}}}},
+		},
+		{
+			name: "no rules",
+			cfg:  ui.NotificationsConfig{},
+		},
+		{
+			name: "rule without events",
+			cfg:  ui.NotificationsConfig{Rules: []ui.NotificationRuleConfig{{Repos: []string{"dhth/prs"}}}},
+		},
+		{
+			name: "unknown event",
+			cfg:  ui.NotificationsConfig{Rules: []ui.NotificationRuleConfig{{Events: []string{"commented"}}}},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := getNotifications(tt.cfg)
+			assert.ErrorIs(t, err, errIncorrectNotifications)
+		})
+	}
+}
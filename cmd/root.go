@@ -11,6 +11,7 @@ import (
 	"time"
 
 	ghapi "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
 	"github.com/dhth/prs/ui"
 	"github.com/spf13/cobra"
 	"github.com/spf13/pflag"
@@ -18,14 +19,20 @@ import (
 )
 
 const (
-	envPrefix          = "PRS"
-	author             = "@dhth"
-	projectHomePage    = "https://github.com/dhth/prs"
-	issuesURL          = "https://github.com/dhth/prs/issues"
-	configFileName     = "prs/prs.yml"
-	defaultSearchQuery = "type:pr author:@me sort:updated-desc state:open"
-	defaultPRNum       = 20
-	maxPRNum           = 50
+	envPrefix            = "PRS"
+	author               = "@dhth"
+	projectHomePage      = "https://github.com/dhth/prs"
+	issuesURL            = "https://github.com/dhth/prs/issues"
+	configFileName       = "prs/prs.yml"
+	stateFileName        = "state.json"
+	cacheDirName         = "prs"
+	defaultSearchQuery   = "type:pr author:@me sort:updated-desc state:open"
+	defaultPRNum         = 20
+	maxPRNum             = 50
+	maxPrefetchBatchSize = 20
+	minRefreshInterval   = 30 * time.Second
+	// used when notifications are set up, but a refresh interval isn't
+	defaultNotificationsRefreshInterval = 5 * time.Minute
 )
 
 var (
@@ -36,6 +43,7 @@ var (
 	errNoReposProvided          = errors.New("no repos were provided")
 	errIncorrectRepoProvided    = errors.New("incorrect repo provided")
 	errCouldntSetupGithubClient = errors.New("couldn't set up a Github Client")
+	errIncorrectFormatProvided  = errors.New("incorrect output format provided")
 )
 
 var reportIssueMsg = fmt.Sprintf("Let %s know about this error via %s.", author, issuesURL)
@@ -59,7 +67,7 @@ This is a fatal error; use --config-path to specify config file path manually.
 	if errors.Is(err, errCouldntSetupGithubClient) {
 		fmt.Printf(`
 If the error is due to misconfigured authentication, you can fix that by either of the following:
-- Provide a valid Github token via $GH_TOKEN
+- Provide a valid Github token via $GH_TOKEN ($GH_ENTERPRISE_TOKEN for Github Enterprise Server hosts)
 - Have an authenticated instance of gh (https://github.com/cli/cli) available
 `)
 	}
@@ -68,15 +76,25 @@ If the error is due to misconfigured authentication, you can fix that by either
 
 func NewRootCommand(version string) (*cobra.Command, error) {
 	var (
-		configFilePath string
-		configPathFull string
-		mode           ui.Mode
-		modeInp        string
-		repoStrs       []string
-		repos          []ui.Repo
-		searchQuery    string
-		ghClient       *ghapi.GraphQLClient
-		prNum          int
+		configFilePath    string
+		configPathFull    string
+		mode              ui.Mode
+		modeInp           string
+		repoStrs          []string
+		repos             []ui.Repo
+		searchQuery       string
+		queries           []ui.NamedQuery
+		ghClient          *ghapi.GraphQLClient
+		prNum             int
+		formatInp         string
+		prefetchWorkers   int
+		prefetchBatchSize int
+		noCache           bool
+		diffPager         string
+		refreshInterval   time.Duration
+		notifications     *ui.Notifications
+		host              string
+		hostClients       map[string]*ghapi.GraphQLClient
 	)
 
 	rootCmd := &cobra.Command{
@@ -97,6 +115,11 @@ $ prs # will read query from config file
 $ prs --mode=repos --repos='dhth/prs,dhth/omm,dhth/hours'
 $ PRS_REPOS='dhth/prs,dhth/omm,dhth/hours' prs --mode=repos
 $ prs -m repos # will read repos from config file
+$ prs -m repos -r 'dhth/prs,ghes.example.com/team/service'
+
+$ prs --host=ghes.example.com -q 'type:pr author:@me state:open'
+
+$ prs list -q 'type:pr author:@me state:open' --format=json
 
 Project home page: %s
 `, projectHomePage),
@@ -120,15 +143,28 @@ Project home page: %s
 				}
 			}
 
+			// named queries from the config file are only used when a query
+			// isn't provided explicitly
+			queryProvided := cmd.Flags().Changed("query") || os.Getenv(fmt.Sprintf("%s_QUERY", envPrefix)) != ""
+
 			var v *viper.Viper
 			v, err = initializeConfig(cmd, configPathFull)
 			if err != nil {
 				return err
 			}
 
-			if prNum > maxPRNum {
-				prNum = maxPRNum
+			if prefetchBatchSize > maxPrefetchBatchSize {
+				prefetchBatchSize = maxPrefetchBatchSize
+			}
+
+			if refreshInterval > 0 && refreshInterval < minRefreshInterval {
+				refreshInterval = minRefreshInterval
+			}
+
+			if host == "" {
+				host, _ = auth.DefaultHost()
 			}
+			host = auth.NormalizeHostname(host)
 
 			switch modeInp {
 			case "repos":
@@ -139,55 +175,167 @@ Project home page: %s
 				return errModeIncorrect
 			}
 
+			var sourceConfig ui.SourceConfig
+			err = v.Unmarshal(&sourceConfig)
+			if err != nil {
+				return fmt.Errorf("%w: %s", errIncorrectSourceProvided, err.Error())
+			}
+
+			numFlag := cmd.Flags().Lookup("num")
+			if sourceConfig.PRCount != nil && numFlag != nil && !numFlag.Changed && !v.IsSet("num") {
+				prNum = *sourceConfig.PRCount
+			}
+
+			if sourceConfig.Notifications != nil {
+				notifications, err = getNotifications(*sourceConfig.Notifications)
+				if err != nil {
+					return err
+				}
+
+				if refreshInterval == 0 {
+					refreshInterval = defaultNotificationsRefreshInterval
+				}
+			}
+
+			if mode == ui.QueryMode && !queryProvided && sourceConfig.Queries != nil {
+				queries, err = getNamedQueries(*sourceConfig.Queries)
+				if err != nil {
+					return err
+				}
+				// prs list uses the first named query
+				searchQuery = queries[0].Query
+			}
+
 			if mode == ui.RepoMode {
 				var reposToUse []string
 				// pretty ugly hack to get around the fact that
 				// v.GetStringSlice("repos") always seems to prioritize the config file
-				if len(repoStrs) > 0 && len(repoStrs[0]) > 0 && !strings.HasPrefix(repoStrs[0], "[") {
+				repoFlagUsed := len(repoStrs) > 0 && len(repoStrs[0]) > 0 && !strings.HasPrefix(repoStrs[0], "[")
+				if repoFlagUsed {
 					reposToUse = repoStrs
 				} else {
 					reposToUse = v.GetStringSlice("repos")
 				}
 
-				if len(reposToUse) == 0 {
-					return errNoReposProvided
+				for _, r := range reposToUse {
+					repo, err := parseRepo(r, host)
+					if err != nil {
+						return err
+					}
+					repos = append(repos, repo)
 				}
 
-				for _, r := range reposToUse {
-					repoEls := strings.Split(r, "/")
-					// TODO: there can be more validations done here, maybe regex based
-					if len(repoEls) != 2 {
-						return fmt.Errorf("%w: %s", errIncorrectRepoProvided, r)
+				if !repoFlagUsed && sourceConfig.Sources != nil {
+					sourceRepos, err := getReposFromSources(*sourceConfig.Sources, host)
+					if err != nil {
+						return err
 					}
+					repos = append(repos, sourceRepos...)
+				}
 
-					repos = append(repos, ui.Repo{
-						Owner: strings.TrimSpace(repoEls[0]),
-						Name:  strings.TrimSpace(repoEls[1]),
-					})
+				if len(repos) == 0 {
+					return errNoReposProvided
 				}
-			}
 
-			opts := ghapi.ClientOptions{
-				EnableCache: true,
-				CacheTTL:    time.Second * 30,
-				Timeout:     8 * time.Second,
+				err = checkForDuplicateRepos(repos)
+				if err != nil {
+					return err
+				}
 			}
 
-			ghClient, err = ghapi.NewGraphQLClient(opts)
+			ghClient, err = newGHClient(host)
 			if err != nil {
 				return fmt.Errorf("%w: %s", errCouldntSetupGithubClient, err.Error())
 			}
+
+			hostClients = make(map[string]*ghapi.GraphQLClient)
+			for _, r := range repos {
+				if r.Host == "" {
+					continue
+				}
+				if _, ok := hostClients[r.Host]; ok {
+					continue
+				}
+
+				hostClients[r.Host], err = newGHClient(r.Host)
+				if err != nil {
+					return fmt.Errorf("%w for host %s: %s", errCouldntSetupGithubClient, r.Host, err.Error())
+				}
+			}
+
 			return nil
 		},
 		RunE: func(_ *cobra.Command, _ []string) error {
+			// the TUI fetches more PRs lazily, so --num only sets the page size here
+			if prNum > maxPRNum {
+				prNum = maxPRNum
+			}
+
+			var cacheDir string
+			if !noCache {
+				userCacheDir, err := os.UserCacheDir()
+				if err == nil {
+					cacheDir = filepath.Join(userCacheDir, cacheDirName)
+				}
+			}
+
+			config := ui.Config{
+				PRCount:           prNum,
+				Repos:             repos,
+				Query:             &searchQuery,
+				Queries:           queries,
+				PrefetchWorkers:   prefetchWorkers,
+				PrefetchBatchSize: prefetchBatchSize,
+				CacheDir:          cacheDir,
+				DiffPager:         diffPager,
+				StateFile:         filepath.Join(filepath.Dir(configPathFull), stateFileName),
+				RefreshInterval:   refreshInterval,
+				Notifications:     notifications,
+				Host:              host,
+				HostSources:       getHostSources(hostClients),
+			}
+			return ui.RenderUI(ui.NewGHSource(ghClient, host), config, mode)
+		},
+	}
+
+	listCmd := &cobra.Command{
+		Use:   "list",
+		Short: "Print PRs to stdout without launching the TUI",
+		Long: `Print PRs to stdout without launching the TUI.
+
+Accepts the same configuration as the TUI (--mode, --query, --repos, --num).
+
+Examples:
+$ prs list -q 'type:pr user-review-requested:@me state:open'
+$ prs list -m repos -r 'dhth/prs,dhth/omm' --format=ndjson
+`,
+		Args:         cobra.MaximumNArgs(0),
+		SilenceUsage: true,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			var format ui.OutputFormat
+			switch formatInp {
+			case "table":
+				format = ui.TableOutput
+			case "json":
+				format = ui.JSONOutput
+			case "ndjson":
+				format = ui.NDJSONOutput
+			default:
+				return fmt.Errorf("%w: %s", errIncorrectFormatProvided, formatInp)
+			}
+
 			config := ui.Config{
-				PRCount: prNum,
-				Repos:   repos,
-				Query:   &searchQuery,
+				PRCount:     prNum,
+				Repos:       repos,
+				Query:       &searchQuery,
+				HostSources: getHostSources(hostClients),
 			}
-			return ui.RenderUI(ghClient, config, mode)
+			return ui.ListPRs(ui.NewGHSource(ghClient, host), config, mode, format, cmd.OutOrStdout())
 		},
 	}
+	listCmd.Flags().StringVarP(&formatInp, "format", "f", "table", "output format; values: table, json, ndjson")
+	rootCmd.AddCommand(listCmd)
+
 	rootCmd.SetVersionTemplate(`{{with .Name}}{{printf "%s " .}}{{end}}{{printf "%s" .Version}}
 `)
 
@@ -210,17 +358,42 @@ Project home page: %s
 		defaultConfigFilePath = filepath.Join(hd, ".config", configFileName)
 	}
 
-	rootCmd.Flags().StringVarP(&configFilePath, "config-path", "c", defaultConfigFilePath, "location of prs's config file")
-	rootCmd.Flags().StringVarP(&modeInp, "mode", "m", "query", "mode to run prs in; values: query, repos")
-	rootCmd.Flags().StringVarP(&searchQuery, "query", "q", defaultSearchQuery, "query to search PRs for")
-	rootCmd.Flags().IntVarP(&prNum, "num", "n", defaultPRNum, "number of PRs to fetch")
-	rootCmd.Flags().StringSliceVarP(&repoStrs, "repos", "r", nil, "comma separated list of repos to use for repo mode")
+	rootCmd.PersistentFlags().StringVarP(&configFilePath, "config-path", "c", defaultConfigFilePath, "location of prs's config file")
+	rootCmd.PersistentFlags().StringVarP(&modeInp, "mode", "m", "query", "mode to run prs in; values: query, repos")
+	rootCmd.PersistentFlags().StringVarP(&searchQuery, "query", "q", defaultSearchQuery, "query to search PRs for")
+	rootCmd.PersistentFlags().IntVarP(&prNum, "num", "n", defaultPRNum, "number of PRs to fetch (page size for the TUI, capped at 50)")
+	rootCmd.PersistentFlags().StringSliceVarP(&repoStrs, "repos", "r", nil, "comma separated list of repos to use for repo mode; repos not on the default host can be provided as host/owner/repo")
+	rootCmd.PersistentFlags().StringVar(&host, "host", "", "Github host to use (defaults to $GH_HOST, or gh's default host)")
+	rootCmd.Flags().IntVar(&prefetchWorkers, "prefetch-workers", ui.DefaultPrefetchWorkers, "maximum number of concurrent requests made when prefetching PR details")
+	rootCmd.Flags().IntVar(&prefetchBatchSize, "prefetch-batch-size", ui.DefaultPrefetchBatchSize, "number of PRs to prefetch details for in a single request")
+	rootCmd.Flags().BoolVar(&noCache, "no-cache", false, "don't persist PR data between runs")
+	rootCmd.Flags().StringVar(&diffPager, "diff-pager", "", "command to pipe PR diffs through (eg. delta, 'less -R'); defaults to gh's pager")
+	rootCmd.Flags().DurationVar(&refreshInterval, "refresh-interval", 0, "how often to refresh the PR list in the background (eg. 5m, at least 30s); off if 0")
 
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
 
 	return rootCmd, nil
 }
 
+func newGHClient(host string) (*ghapi.GraphQLClient, error) {
+	opts := ghapi.ClientOptions{
+		Host:        host,
+		EnableCache: true,
+		CacheTTL:    time.Second * 30,
+		Timeout:     8 * time.Second,
+	}
+
+	return ghapi.NewGraphQLClient(opts)
+}
+
+func getHostSources(hostClients map[string]*ghapi.GraphQLClient) map[string]*ui.GHSource {
+	sources := make(map[string]*ui.GHSource, len(hostClients))
+	for host, client := range hostClients {
+		sources[host] = ui.NewGHSource(client, host)
+	}
+	return sources
+}
+
 func initializeConfig(cmd *cobra.Command, configFile string) (*viper.Viper, error) {
 	v := viper.New()
 
@@ -0,0 +1,172 @@
+package ui
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// bump this whenever the shape of cached data changes, so that entries
+// written by older versions are ignored
+const diskCacheVersion = 1
+
+var errCacheEntryVersionMismatch = errors.New("cache entry was written by a different version")
+
+// diskCache persists PR data between runs, so that prs can show the last known
+// state of PRs while fresh data is being fetched. A nil *diskCache is valid,
+// and caches nothing.
+type diskCache struct {
+	dir string
+}
+
+type cachedPR struct {
+	Version     int        `json:"version"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+	ChecksState string     `json:"checks_state"`
+	Details     prDetails  `json:"details"`
+	TLItems     []prTLItem `json:"timeline_items"`
+	TLPage      tlPageInfo `json:"timeline_page_info"`
+}
+
+type cachedQueryResults struct {
+	Version   int       `json:"version"`
+	Host      string    `json:"host"`
+	Query     string    `json:"query"`
+	FetchedAt time.Time `json:"fetched_at"`
+	PRs       []pr      `json:"prs"`
+}
+
+func newDiskCache(dir string) *diskCache {
+	if dir == "" {
+		return nil
+	}
+	return &diskCache{dir: dir}
+}
+
+// prPath returns where a PR's data is cached; repos on different hosts can
+// share a name, so the host is part of it.
+func (c *diskCache) prPath(p prRef) string {
+	return filepath.Join(c.dir, "prs", p.host, p.repoOwner, p.repoName, fmt.Sprintf("%d.json", p.prNumber))
+}
+
+// queryPath returns where the results of a query run against a host are
+// cached.
+func (c *diskCache) queryPath(host, query string) string {
+	h := sha256.Sum256([]byte(host + "\n" + query))
+	return filepath.Join(c.dir, "queries", hex.EncodeToString(h[:8])+".json")
+}
+
+// getPR returns the cached data for a PR, provided it's as recent as p. Check
+// runs finishing doesn't bump a PR's updatedAt, so the state of its checks
+// needs to match as well.
+func (c *diskCache) getPR(p prRef) (prData, bool) {
+	if c == nil {
+		return prData{}, false
+	}
+
+	var entry cachedPR
+	err := readCacheFile(c.prPath(p), &entry)
+	if err != nil || entry.Version != diskCacheVersion {
+		return prData{}, false
+	}
+
+	if !entry.UpdatedAt.Equal(p.updatedAt) || entry.ChecksState != p.checksState {
+		return prData{}, false
+	}
+
+	return prData{details: entry.Details, tlItems: entry.TLItems, tlPageInfo: entry.TLPage}, true
+}
+
+func (c *diskCache) savePR(p prRef, data prData) error {
+	if c == nil {
+		return nil
+	}
+
+	return writeCacheFile(c.prPath(p), cachedPR{
+		Version:     diskCacheVersion,
+		UpdatedAt:   p.updatedAt,
+		ChecksState: p.checksState,
+		Details:     data.details,
+		TLItems:     data.tlItems,
+		TLPage:      data.tlPageInfo,
+	})
+}
+
+func (c *diskCache) getQueryResults(host, query string) ([]pr, error) {
+	if c == nil {
+		return nil, nil
+	}
+
+	var entry cachedQueryResults
+	err := readCacheFile(c.queryPath(host, query), &entry)
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Version != diskCacheVersion || entry.Host != host || entry.Query != query {
+		return nil, errCacheEntryVersionMismatch
+	}
+
+	return entry.PRs, nil
+}
+
+func (c *diskCache) saveQueryResults(host, query string, prs []pr) error {
+	if c == nil {
+		return nil
+	}
+
+	return writeCacheFile(c.queryPath(host, query), cachedQueryResults{
+		Version:   diskCacheVersion,
+		Host:      host,
+		Query:     query,
+		FetchedAt: time.Now(),
+		PRs:       prs,
+	})
+}
+
+func readCacheFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(data, v)
+}
+
+// writeCacheFile writes v to path via a temporary file, so that a concurrent
+// reader never sees a partially written entry.
+func writeCacheFile(path string, v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0o755)
+	if err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+
+	_, err = f.Write(data)
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	err = f.Close()
+	if err != nil {
+		return err
+	}
+
+	return os.Rename(f.Name(), path)
+}
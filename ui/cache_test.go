@@ -0,0 +1,82 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCacheInvalidatesOnUpdatedAt(t *testing.T) {
+	cache := newDiskCache(t.TempDir())
+	updatedAt := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	ref := prRef{repoOwner: "dhth", repoName: "prs", prNumber: 12, updatedAt: updatedAt}
+
+	err := cache.savePR(ref, prData{details: prDetails{Number: 12, PRTitle: "cached"}})
+	require.NoError(t, err)
+
+	got, ok := cache.getPR(ref)
+	require.True(t, ok)
+	assert.Equal(t, "cached", got.details.PRTitle)
+
+	ref.updatedAt = updatedAt.Add(time.Minute)
+	_, ok = cache.getPR(ref)
+	assert.False(t, ok)
+}
+
+func TestDiskCacheInvalidatesOnChecksState(t *testing.T) {
+	cache := newDiskCache(t.TempDir())
+	updatedAt := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	ref := prRef{repoOwner: "dhth", repoName: "prs", prNumber: 12, updatedAt: updatedAt, checksState: checksStatePending}
+
+	err := cache.savePR(ref, prData{details: prDetails{Number: 12}})
+	require.NoError(t, err)
+
+	// check runs finishing leaves updatedAt as it was
+	ref.checksState = statusStateFailure
+	_, ok := cache.getPR(ref)
+	assert.False(t, ok)
+}
+
+func TestDiskCacheKeysEntriesByHost(t *testing.T) {
+	cache := newDiskCache(t.TempDir())
+	updatedAt := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	ref := prRef{host: "github.com", repoOwner: "dhth", repoName: "prs", prNumber: 1, updatedAt: updatedAt}
+	require.NoError(t, cache.savePR(ref, prData{}))
+	require.NoError(t, cache.saveQueryResults("github.com", "type:pr author:@me", []pr{{Number: 1}}))
+
+	ref.host = "ghes.example.com"
+	_, ok := cache.getPR(ref)
+	assert.False(t, ok)
+
+	_, err := cache.getQueryResults("ghes.example.com", "type:pr author:@me")
+	assert.Error(t, err)
+}
+
+func TestNilDiskCacheCachesNothing(t *testing.T) {
+	var cache *diskCache
+
+	require.NoError(t, cache.savePR(prRef{}, prData{}))
+	_, ok := cache.getPR(prRef{})
+	assert.False(t, ok)
+}
+
+func TestPrefetchPRsOnlyFetchesStalePRs(t *testing.T) {
+	cache := newDiskCache(t.TempDir())
+	updatedAt := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+	fresh := prRef{repoOwner: "dhth", repoName: "prs", prNumber: 1, updatedAt: updatedAt}
+	stale := prRef{repoOwner: "dhth", repoName: "prs", prNumber: 2, updatedAt: updatedAt}
+	require.NoError(t, cache.savePR(fresh, prData{details: prDetails{PRTitle: "from cache"}}))
+
+	src := &fakePRSource{details: map[int]prDetails{1: {PRTitle: "from source"}, 2: {PRTitle: "from source"}}}
+
+	msg, ok := prefetchPRs(src, cache, []prRef{fresh, stale})().(prsPrefetchedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	assert.Equal(t, "from cache", msg.data[0].details.PRTitle)
+	assert.Equal(t, "from source", msg.data[1].details.PRTitle)
+
+	_, ok = cache.getPR(stale)
+	assert.True(t, ok)
+}
@@ -0,0 +1,369 @@
+package ui
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	ghapi "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	githubActionsAppSlug = "github-actions"
+	jobLogTailLines      = 200
+	// lines longer than this fail the log's fetch, rather than being held in
+	// memory
+	jobLogMaxLineSize = 1024 * 1024
+	// logs are downloaded from wherever Github redirects to, which can take a
+	// while for long ones
+	actionsClientTimeout = time.Minute
+)
+
+var (
+	errCouldntFetchJobLog = errors.New("couldn't fetch job log")
+
+	// lines in Github Actions logs start with a timestamp, eg.
+	// 2025-01-01T10:00:00.1234567Z
+	jobLogTimestampRegex = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T[0-9:.]+Z `)
+	ansiEscapeRegex      = regexp.MustCompile(`\x1b\[[0-9;]*[A-Za-z]`)
+)
+
+// checkRunDetails is what's shown for a check run in the check run detail
+// view; the log is only fetched for Github Actions jobs.
+type checkRunDetails struct {
+	run    checkRun
+	log    string
+	logErr error
+}
+
+// checkRunItem is a check run on a PR's latest commit, as shown in the check
+// run list.
+type checkRunItem struct {
+	id         string
+	name       string
+	status     string
+	conclusion *string
+	detailsURL *string
+}
+
+func (i checkRunItem) Title() string {
+	return i.name
+}
+
+func (i checkRunItem) Description() string {
+	if i.conclusion == nil {
+		return strings.ToLower(i.status)
+	}
+
+	switch *i.conclusion {
+	case checkConclusionStateSuccess:
+		return "✅ success"
+	case checkConclusionStateFailure, checkConclusionStateError:
+		return "❌ " + strings.ToLower(*i.conclusion)
+	default:
+		return strings.ToLower(*i.conclusion)
+	}
+}
+
+func (i checkRunItem) FilterValue() string {
+	return i.name
+}
+
+func newCheckRunListDel() list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+
+	d.Styles.SelectedTitle = d.Styles.
+		SelectedTitle.
+		Foreground(lipgloss.Color(checkRunListColor)).
+		BorderLeftForeground(lipgloss.Color(checkRunListColor))
+	d.Styles.SelectedDesc = d.Styles.
+		SelectedTitle
+
+	return d
+}
+
+// getCheckRunItems returns the check runs on a PR's latest commit; status
+// contexts are left out, as there's nothing more to show for them.
+func getCheckRunItems(details prDetails) []list.Item {
+	if len(details.LastCommit.Nodes) == 0 || details.LastCommit.Nodes[0].Commit.StatusCheckRollup == nil {
+		return nil
+	}
+
+	var items []list.Item
+	for _, n := range details.LastCommit.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+		if n.Type != checkRunType {
+			continue
+		}
+		items = append(items, checkRunItem{
+			id:         n.CheckRun.ID,
+			name:       n.CheckRun.Name,
+			status:     n.CheckRun.Status,
+			conclusion: n.CheckRun.Conclusion,
+			detailsURL: n.CheckRun.DetailsURL,
+		})
+	}
+	return items
+}
+
+// showCheckRuns lists the check runs on the selected PR's latest commit.
+func (m *Model) showCheckRuns() {
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return
+	}
+
+	details, ok := m.prDetailsCache[prRes.identifier]
+	if !ok {
+		m.message = "PR details were not retrieved"
+		return
+	}
+
+	items := getCheckRunItems(details)
+	if len(items) == 0 {
+		m.message = "there are no check runs on the latest commit"
+		return
+	}
+
+	m.checkRunList.SetItems(items)
+	m.checkRunList.ResetSelected()
+	m.checkRunList.Title = fmt.Sprintf("Check runs (#%d)", prRes.pr.Number)
+	m.activePane = checkRunListView
+}
+
+func (m Model) updateCheckRunListView(msg tea.KeyPressMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "q", "esc", "ctrl+c":
+		m.activePane = prDetailsView
+		return m, nil
+	case "Q":
+		return m, tea.Quit
+	case "enter":
+		return m, m.openCheckRun()
+	case "R":
+		m.startChecksRerun()
+		return m, nil
+	case "ctrl+b":
+		item, ok := m.checkRunList.SelectedItem().(checkRunItem)
+		if !ok || item.detailsURL == nil {
+			return m, nil
+		}
+		return m, openURLInBrowser(*item.detailsURL)
+	}
+
+	var cmd tea.Cmd
+	m.checkRunList, cmd = m.checkRunList.Update(msg)
+	return m, cmd
+}
+
+// openCheckRun shows the details of the check run under the cursor, fetching
+// them unless they're cached; only check runs that had completed, and whose
+// log (if any) could be fetched, are cached.
+func (m *Model) openCheckRun() tea.Cmd {
+	item, ok := m.checkRunList.SelectedItem().(checkRunItem)
+	if !ok {
+		return nil
+	}
+
+	if cached, ok := m.checkRunCache[item.id]; ok {
+		m.showCheckRun(cached)
+		return nil
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	m.message = "fetching check run..."
+	return fetchCheckRun(m.prSourceForHost(getPRHost(prRes.pr)), prRes, item.id)
+}
+
+func fetchCheckRun(prSource prDataSource, prRes *prResult, checkRunID string) tea.Cmd {
+	repoOwner := prRes.pr.Repository.Owner.Login
+	repoName := prRes.pr.Repository.Name
+
+	return func() tea.Msg {
+		msg := checkRunFetchedMsg{checkRunID: checkRunID}
+
+		src, ok := prSource.(checkRunSource)
+		if !ok {
+			msg.err = errActionNotSupported
+			return msg
+		}
+
+		msg.details.run, msg.err = src.GetCheckRun(checkRunID)
+		if msg.err != nil {
+			return msg
+		}
+
+		run := msg.details.run
+		if run.DatabaseID == nil || run.CheckSuite.App == nil || run.CheckSuite.App.Slug != githubActionsAppSlug {
+			return msg
+		}
+
+		// the check runs Github Actions creates share their IDs with the
+		// jobs they're for
+		msg.details.log, msg.details.logErr = src.GetJobLogTail(repoOwner, repoName, *run.DatabaseID, jobLogTailLines)
+		return msg
+	}
+}
+
+// newActionsClient returns a REST client for Github Actions' API on host; an
+// empty host uses the default host.
+func newActionsClient(host string) (*ghapi.RESTClient, error) {
+	return ghapi.NewRESTClient(ghapi.ClientOptions{
+		Host:    host,
+		Timeout: actionsClientTimeout,
+	})
+}
+
+// getJobLogTail fetches the log of a Github Actions job, and returns its last
+// n lines; the API redirects to where the log can be downloaded from, which
+// the client follows.
+func getJobLogTail(client restRequester, repoOwner, repoName string, jobID int, n int) (string, error) {
+	path := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", url.PathEscape(repoOwner), url.PathEscape(repoName), jobID)
+	resp, err := client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errCouldntFetchJobLog, err.Error())
+	}
+	defer resp.Body.Close()
+
+	log, err := tailJobLog(resp.Body, n)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errCouldntFetchJobLog, err.Error())
+	}
+
+	return log, nil
+}
+
+// tailJobLog returns the last n lines of a job's log, without the timestamps
+// and color codes Github Actions adds to them. Logs can run into hundreds of
+// megabytes, so only the last n lines are held on to while it's read.
+func tailJobLog(r io.Reader, n int) (string, error) {
+	if n <= 0 {
+		return "", nil
+	}
+
+	ring := make([]string, n)
+	var count int
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), jobLogMaxLineSize)
+	for scanner.Scan() {
+		ring[count%n] = scanner.Text()
+		count++
+	}
+	if err := scanner.Err(); err != nil {
+		return "", err
+	}
+
+	lines := make([]string, 0, min(count, n))
+	for i := max(count-n, 0); i < count; i++ {
+		line := strings.TrimPrefix(ring[i%n], "\ufeff")
+		line = jobLogTimestampRegex.ReplaceAllString(line, "")
+		lines = append(lines, ansiEscapeRegex.ReplaceAllString(line, ""))
+	}
+
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n"), nil
+}
+
+// getCheckRunContent renders a check run's summary, annotations and log in
+// markdown.
+func getCheckRunContent(details checkRunDetails) string {
+	run := details.run
+
+	state := run.Status
+	if run.Conclusion != nil {
+		state = *run.Conclusion
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s `%s`\n", run.Name, state)
+
+	if run.StartedAt != nil {
+		if run.CompletedAt != nil {
+			fmt.Fprintf(&b, "\n> started %s, took %s\n",
+				humanize.Time(*run.StartedAt),
+				run.CompletedAt.Sub(*run.StartedAt).Round(time.Second).String(),
+			)
+		} else {
+			fmt.Fprintf(&b, "\n> started %s\n", humanize.Time(*run.StartedAt))
+		}
+	}
+
+	if run.Title != nil && *run.Title != "" {
+		fmt.Fprintf(&b, "\n## %s\n", *run.Title)
+	}
+	if run.Summary != nil && *run.Summary != "" {
+		fmt.Fprintf(&b, "\n%s\n", *run.Summary)
+	}
+
+	if len(run.Annotations.Nodes) > 0 {
+		b.WriteString("\n## Annotations\n\n")
+		for _, a := range run.Annotations.Nodes {
+			b.WriteString(getCheckAnnotationLine(a) + "\n")
+		}
+		if run.Annotations.TotalCount > len(run.Annotations.Nodes) {
+			fmt.Fprintf(&b, "\n> showing %d of %d annotations\n", len(run.Annotations.Nodes), run.Annotations.TotalCount)
+		}
+	}
+
+	switch {
+	case details.logErr != nil:
+		fmt.Fprintf(&b, "\n## Log\n\n> %s\n", details.logErr.Error())
+	case details.log != "":
+		fmt.Fprintf(&b, "\n## Log (last %d lines)\n\n```text\n%s\n```\n", jobLogTailLines, details.log)
+	}
+
+	return b.String()
+}
+
+func getCheckAnnotationLine(a checkAnnotation) string {
+	location := fmt.Sprintf("%s:%d", a.Path, a.Location.Start.Line)
+	if a.Location.End.Line > a.Location.Start.Line {
+		location += fmt.Sprintf("-%d", a.Location.End.Line)
+	}
+
+	var level string
+	if a.AnnotationLevel != nil {
+		level = fmt.Sprintf(" **%s**", strings.ToLower(*a.AnnotationLevel))
+	}
+
+	message := strings.Join(strings.Fields(a.Message), " ")
+	if a.Title != nil && *a.Title != "" {
+		message = fmt.Sprintf("%s: %s", *a.Title, message)
+	}
+
+	return fmt.Sprintf("- `%s`%s %s", location, level, message)
+}
+
+func (m *Model) showCheckRun(details checkRunDetails) {
+	content := getCheckRunContent(details)
+
+	glErr := true
+	if m.mdRenderer != nil {
+		contentGl, err := m.mdRenderer.Render(content)
+		if err == nil {
+			m.checkRunVP.SetContent(contentGl)
+			glErr = false
+		}
+	}
+	if glErr {
+		m.checkRunVP.SetContent(content)
+	}
+
+	m.checkRunTitle = fmt.Sprintf("Check run: %s", details.run.Name)
+	m.checkRunDetailsURL = details.run.DetailsURL
+	m.checkRunVP.GotoTop()
+	m.activePane = checkRunDetailView
+}
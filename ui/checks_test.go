@@ -0,0 +1,227 @@
+package ui
+
+import (
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeCheckRunSource struct {
+	fakePRSource
+	runs       map[string]checkRun
+	logErr     error
+	numFetches int
+}
+
+func (s *fakeCheckRunSource) GetCheckRun(checkRunID string) (checkRun, error) {
+	s.numFetches++
+	return s.runs[checkRunID], nil
+}
+
+func (s *fakeCheckRunSource) GetJobLogTail(_, _ string, _ int, _ int) (string, error) {
+	return "exit code 1", s.logErr
+}
+
+func detailsWithChecks() prDetails {
+	details := prDetails{Number: 1}
+	details.Repository.ID = "R_1"
+	details.Repository.Owner.Login = "dhth"
+	details.Repository.Name = "prs"
+	details.LastCommit.Nodes = make([]prLastCommitNode, 1)
+	rollup := &prStatusCheckRollup{State: statusStateFailure}
+
+	nodes := slices.Grow(rollup.Contexts.Nodes, 3)[:3]
+	failure := checkConclusionStateFailure
+	nodes[0].Type = checkRunType
+	nodes[0].CheckRun.ID = "CR_1"
+	nodes[0].CheckRun.Name = "build"
+	nodes[0].CheckRun.Status = checkStatusStateCompleted
+	nodes[0].CheckRun.Conclusion = &failure
+	nodes[0].CheckRun.CheckSuite.ID = "CS_1"
+	nodes[1].Type = statusContextType
+	nodes[1].StatusContext.Context = "ci/legacy"
+	nodes[2].Type = checkRunType
+	nodes[2].CheckRun.ID = "CR_2"
+	nodes[2].CheckRun.Name = "lint"
+	nodes[2].CheckRun.Status = "IN_PROGRESS"
+	nodes[2].CheckRun.CheckSuite.ID = "CS_2"
+	rollup.Contexts.Nodes = nodes
+
+	details.LastCommit.Nodes[0].Commit.StatusCheckRollup = rollup
+	return details
+}
+
+func TestGetCheckRunItemsLeavesOutStatusContexts(t *testing.T) {
+	items := getCheckRunItems(detailsWithChecks())
+
+	require.Len(t, items, 2)
+	assert.Equal(t, "build", items[0].(checkRunItem).Title())
+	assert.Equal(t, "❌ failure", items[0].(checkRunItem).Description())
+	assert.Equal(t, "in_progress", items[1].(checkRunItem).Description())
+}
+
+func TestGetJobLogTail(t *testing.T) {
+	client := &fakeRESTClient{body: "first\nsecond\nthird\n"}
+
+	log, err := getJobLogTail(client, "dhth", "prs", 123, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "repos/dhth/prs/actions/jobs/123/logs", client.path)
+	assert.Equal(t, "second\nthird", log)
+}
+
+func TestGetJobLogTailReturnsErrorOnFailedRequest(t *testing.T) {
+	client := &fakeRESTClient{err: io.ErrUnexpectedEOF}
+
+	_, err := getJobLogTail(client, "dhth", "prs", 123, 2)
+	assert.ErrorIs(t, err, errCouldntFetchJobLog)
+}
+
+func TestTailJobLog(t *testing.T) {
+	log := "\ufeff2025-01-01T10:00:00.1234567Z first\r\n" +
+		"2025-01-01T10:00:01.1234567Z \x1b[36;1mgo test ./...\x1b[0m\r\n" +
+		"2025-01-01T10:00:02.1234567Z ##[error]Process completed with exit code 1.\r\n"
+
+	testCases := []struct {
+		name     string
+		log      string
+		n        int
+		expected string
+	}{
+		{"fewer lines than asked for", log, 10, "first\ngo test ./...\n##[error]Process completed with exit code 1."},
+		{"more lines than asked for", log, 2, "go test ./...\n##[error]Process completed with exit code 1."},
+		{"as many lines as asked for", log, 3, "first\ngo test ./...\n##[error]Process completed with exit code 1."},
+		{"trailing empty lines", "done\n\n\n", 10, "done"},
+		{"empty log", "", 10, ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tailJobLog(strings.NewReader(tt.log), tt.n)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, got)
+		})
+	}
+}
+
+func TestGetCheckRunContent(t *testing.T) {
+	failure := checkConclusionStateFailure
+	level := "FAILURE"
+	title := "build failed"
+	run := checkRun{Name: "build", Status: checkStatusStateCompleted, Conclusion: &failure, Title: &title}
+	run.Annotations.TotalCount = 3
+	run.Annotations.Nodes = make([]checkAnnotation, 2)
+	run.Annotations.Nodes[0].Path = "ui/checks.go"
+	run.Annotations.Nodes[0].Location.Start.Line = 10
+	run.Annotations.Nodes[0].Location.End.Line = 12
+	run.Annotations.Nodes[0].AnnotationLevel = &level
+	run.Annotations.Nodes[0].Message = "undefined:\n  foo"
+	run.Annotations.Nodes[1].Path = "ui/gh.go"
+	run.Annotations.Nodes[1].Location.Start.Line = 4
+	run.Annotations.Nodes[1].Location.End.Line = 4
+	run.Annotations.Nodes[1].Message = "unused import"
+
+	got := getCheckRunContent(checkRunDetails{run: run, log: "exit code 1"})
+
+	assert.Contains(t, got, "# build `FAILURE`")
+	assert.Contains(t, got, "## build failed")
+	assert.Contains(t, got, "- `ui/checks.go:10-12` **failure** undefined: foo")
+	assert.Contains(t, got, "- `ui/gh.go:4` unused import")
+	assert.Contains(t, got, "showing 2 of 3 annotations")
+	assert.Contains(t, got, "```text\nexit code 1\n```")
+}
+
+func TestOpeningCheckRuns(t *testing.T) {
+	src := &fakeCheckRunSource{runs: map[string]checkRun{
+		"CR_1": {ID: "CR_1", Name: "build", Status: checkStatusStateCompleted},
+		"CR_2": {ID: "CR_2", Name: "lint", Status: "IN_PROGRESS"},
+	}}
+	m := newTestModel(t, src, pr{Number: 1})
+	m.prDetailsCache[m.prCache[0].identifier] = detailsWithChecks()
+	m.activePane = prDetailsView
+
+	// check runs are only listed from the checks section
+	updated, _ := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(Model)
+	assert.Equal(t, prDetailsView, m.activePane)
+
+	m.prDetailsCurrentSection = uint(PRChecks)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+	m = updated.(Model)
+	require.Equal(t, checkRunListView, m.activePane)
+	require.Len(t, m.checkRunList.Items(), 2)
+
+	openCheckRun := func() {
+		t.Helper()
+		updated, cmd := m.Update(tea.KeyPressMsg{Code: tea.KeyEnter})
+		m = updated.(Model)
+		if cmd == nil {
+			return
+		}
+		msg, ok := cmd().(checkRunFetchedMsg)
+		require.True(t, ok)
+		updated, _ = m.Update(msg)
+		m = updated.(Model)
+	}
+
+	openCheckRun()
+	assert.Equal(t, checkRunDetailView, m.activePane)
+	assert.Equal(t, "Check run: build", m.checkRunTitle)
+	assert.Equal(t, 1, src.numFetches)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	m = updated.(Model)
+	require.Equal(t, checkRunListView, m.activePane)
+
+	// completed check runs aren't fetched again
+	openCheckRun()
+	assert.Equal(t, checkRunDetailView, m.activePane)
+	assert.Equal(t, 1, src.numFetches)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	m = updated.(Model)
+	m.checkRunList.Select(1)
+
+	// ones that are still going are
+	openCheckRun()
+	assert.Equal(t, "Check run: lint", m.checkRunTitle)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	m = updated.(Model)
+	openCheckRun()
+	assert.Equal(t, 3, src.numFetches)
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	m = updated.(Model)
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'q', Text: "q"})
+	m = updated.(Model)
+	assert.Equal(t, prDetailsView, m.activePane)
+}
+
+func TestCheckRunsWithLogErrorsAreFetchedAgain(t *testing.T) {
+	jobID := 42
+	run := checkRun{ID: "CR_1", Name: "build", Status: checkStatusStateCompleted, DatabaseID: &jobID}
+	run.CheckSuite.App = &struct {
+		Slug string
+	}{githubActionsAppSlug}
+	src := &fakeCheckRunSource{runs: map[string]checkRun{"CR_1": run}, logErr: errCouldntFetchJobLog}
+
+	msg, ok := fetchCheckRun(src, &prResult{pr: &pr{Number: 1}}, "CR_1")().(checkRunFetchedMsg)
+	require.True(t, ok)
+	require.ErrorIs(t, msg.details.logErr, errCouldntFetchJobLog)
+
+	m := newTestModel(t, src, pr{Number: 1})
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	assert.NotContains(t, m.checkRunCache, "CR_1")
+
+	src.logErr = nil
+	msg, ok = fetchCheckRun(src, &prResult{pr: &pr{Number: 1}}, "CR_1")().(checkRunFetchedMsg)
+	require.True(t, ok)
+	updated, _ = m.Update(msg)
+	m = updated.(Model)
+	assert.Equal(t, "exit code 1", m.checkRunCache["CR_1"].log)
+}
@@ -3,17 +3,18 @@ package ui
 import (
 	"errors"
 	"fmt"
+	"os"
 	"os/exec"
 	"runtime"
+	"strings"
 	"time"
 
 	tea "charm.land/bubbletea/v2"
-	ghapi "github.com/cli/go-gh/v2/pkg/api"
 )
 
 var errOSNotSupported = errors.New("OS not supported")
 
-func chooseRepo(repo string) tea.Cmd {
+func chooseRepo(repo Repo) tea.Cmd {
 	return func() tea.Msg {
 		return repoChosenMsg{repo}
 	}
@@ -45,16 +46,38 @@ func openURLInBrowser(url string) tea.Cmd {
 	}
 }
 
-func showDiff(repoOwner, repoName string, prNumber int) tea.Cmd {
+// getGHRepoArg returns the value to pass to gh's --repo flag for a PR; the
+// host is included so that gh doesn't fall back to its default host.
+func getGHRepoArg(pr *pr) string {
+	repo := fmt.Sprintf("%s/%s", pr.Repository.Owner.Login, pr.Repository.Name)
+
+	host := getPRHost(pr)
+	if host == "" {
+		return repo
+	}
+	return fmt.Sprintf("%s/%s", host, repo)
+}
+
+func isGHAvailable() bool {
+	_, err := exec.LookPath("gh")
+	return err == nil
+}
+
+// showDiff shows a PR's diff using gh, which pipes it through pager if one is
+// provided, or through gh's own pager otherwise.
+func showDiff(repoArg string, prNumber int, pager string) tea.Cmd {
 	cmd := []string{
 		"gh",
 		"--repo",
-		fmt.Sprintf("%s/%s", repoOwner, repoName),
+		repoArg,
 		"pr",
 		"diff",
 		fmt.Sprintf("%d", prNumber),
 	}
 	c := exec.Command(cmd[0], cmd[1:]...)
+	if pager != "" {
+		c.Env = append(os.Environ(), fmt.Sprintf("GH_PAGER=%s", pager))
+	}
 
 	return tea.ExecProcess(c, func(err error) tea.Msg {
 		if err != nil {
@@ -64,11 +87,52 @@ func showDiff(repoOwner, repoName string, prNumber int) tea.Cmd {
 	})
 }
 
-func showPR(repoOwner, repoName string, prNumber int) tea.Cmd {
+func fetchPRDiff(prSource prDataSource, prRes *prResult, showInPager bool) tea.Cmd {
+	return func() tea.Msg {
+		msg := prDiffFetchedMsg{
+			identifier:  prRes.identifier,
+			updatedAt:   prRes.pr.UpdatedAt,
+			showInPager: showInPager,
+		}
+
+		diffSource, ok := prSource.(prDiffSource)
+		if !ok {
+			msg.err = errActionNotSupported
+			return msg
+		}
+
+		msg.diff, msg.err = diffSource.GetPRDiff(prRes.pr.Repository.Owner.Login, prRes.pr.Repository.Name, prRes.pr.Number)
+		return msg
+	}
+}
+
+// showDiffInPager pipes a diff through pager, falling back to $PAGER, and
+// then to "less -R".
+func showDiffInPager(diff string, pager string) tea.Cmd {
+	if pager == "" {
+		pager = os.Getenv("PAGER")
+	}
+	if pager == "" {
+		pager = "less -R"
+	}
+
+	pagerEls := strings.Fields(pager)
+	c := exec.Command(pagerEls[0], pagerEls[1:]...)
+	c.Stdin = strings.NewReader(diff)
+
+	return tea.ExecProcess(c, func(err error) tea.Msg {
+		if err != nil {
+			return prDiffDoneMsg{err: err}
+		}
+		return tea.Msg(prDiffDoneMsg{})
+	})
+}
+
+func showPR(repoArg string, prNumber int) tea.Cmd {
 	cmd := []string{
 		"gh",
 		"--repo",
-		fmt.Sprintf("%s/%s", repoOwner, repoName),
+		repoArg,
 		"pr",
 		"view",
 		"--comments",
@@ -84,37 +148,72 @@ func showPR(repoOwner, repoName string, prNumber int) tea.Cmd {
 	})
 }
 
+func loadCachedPRs(cache *diskCache, host, queryStr string) tea.Cmd {
+	return func() tea.Msg {
+		prs, err := cache.getQueryResults(host, queryStr)
+		if err != nil {
+			return nil
+		}
+		return cachedPRsLoadedMsg{queryStr, prs}
+	}
+}
+
+func saveQueryResultsToCache(cache *diskCache, host, queryStr string, prs []pr) tea.Cmd {
+	return func() tea.Msg {
+		_ = cache.saveQueryResults(host, queryStr, prs)
+		return nil
+	}
+}
+
+func getRepoPRsQuery(repoOwner, repoName string) string {
+	return fmt.Sprintf("type:pr repo:%s/%s sort:updated-desc", repoOwner, repoName)
+}
+
 func hideHelp(interval time.Duration) tea.Cmd {
 	return tea.Tick(interval, func(time.Time) tea.Msg {
 		return hideHelpMsg{}
 	})
 }
 
-func fetchPRSFromQuery(ghClient *ghapi.GraphQLClient, queryStr string, prCount int) tea.Cmd {
+func fetchPRSFromQuery(prSource prDataSource, queryStr string, prCount int) tea.Cmd {
+	return func() tea.Msg {
+		prs, pageInfo, err := prSource.SearchPRs(queryStr, prCount, nil)
+		return prsFetchedMsg{queryStr, prs, pageInfo, err}
+	}
+}
+
+func fetchPRSForRepo(prSource prDataSource, repoOwner string, repoName string, prCount int) tea.Cmd {
+	return func() tea.Msg {
+		queryStr := getRepoPRsQuery(repoOwner, repoName)
+		prs, pageInfo, err := prSource.SearchPRs(queryStr, prCount, nil)
+		return prsFetchedMsg{queryStr, prs, pageInfo, err}
+	}
+}
+
+func fetchMorePRs(prSource prDataSource, queryStr string, generation int, prCount int, after *string) tea.Cmd {
 	return func() tea.Msg {
-		prs, err := getPRDataFromQuery(ghClient, queryStr, prCount)
-		return prsFetchedMsg{prs, err}
+		prs, pageInfo, err := prSource.SearchPRs(queryStr, prCount, after)
+		return morePRsFetchedMsg{queryStr, generation, prs, pageInfo, err}
 	}
 }
 
-func fetchPRSForRepo(ghClient *ghapi.GraphQLClient, repoOwner string, repoName string, prCount int) tea.Cmd {
+func fetchPRMetadata(prSource prDataSource, identifier, repoOwner, repoName string, prNumber int) tea.Cmd {
 	return func() tea.Msg {
-		queryStr := fmt.Sprintf("type:pr repo:%s/%s sort:updated-desc", repoOwner, repoName)
-		prs, err := getPRDataFromQuery(ghClient, queryStr, prCount)
-		return prsFetchedMsg{prs, err}
+		metadata, err := prSource.GetPRDetails(repoOwner, repoName, prNumber)
+		return prMetadataFetchedMsg{identifier, metadata, err}
 	}
 }
 
-func fetchPRMetadata(ghClient *ghapi.GraphQLClient, repoOwner, repoName string, prNumber int) tea.Cmd {
+func fetchPRTLItems(prSource prDataSource, identifier, repoOwner string, repoName string, prNumber int, tlItemsCount int, setItems bool) tea.Cmd {
 	return func() tea.Msg {
-		metadata, err := getPRMetadata(ghClient, repoOwner, repoName, prNumber)
-		return prMetadataFetchedMsg{repoOwner, repoName, prNumber, metadata, err}
+		prTLItems, pageInfo, err := prSource.GetPRTimeline(repoOwner, repoName, prNumber, tlItemsCount, nil)
+		return prTLFetchedMsg{identifier, prNumber, prTLItems, pageInfo, setItems, err}
 	}
 }
 
-func fetchPRTLItems(ghClient *ghapi.GraphQLClient, repoOwner string, repoName string, prNumber int, tlItemsCount int, setItems bool) tea.Cmd {
+func fetchEarlierPRTLItems(prSource prDataSource, identifier, repoOwner, repoName string, prNumber int, tlItemsCount int, before *string) tea.Cmd {
 	return func() tea.Msg {
-		prTLItems, err := getPRTLData(ghClient, repoOwner, repoName, prNumber, tlItemsCount)
-		return prTLFetchedMsg{repoOwner, repoName, prNumber, prTLItems, setItems, err}
+		prTLItems, pageInfo, err := prSource.GetPRTimeline(repoOwner, repoName, prNumber, tlItemsCount, before)
+		return earlierPRTLItemsFetchedMsg{identifier, prTLItems, pageInfo, err}
 	}
 }
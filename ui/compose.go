@@ -0,0 +1,69 @@
+package ui
+
+import (
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// composer describes what's being written in the compose view, and what to do
+// with it once it's submitted.
+type composer struct {
+	title string
+	// emptyBodyMsg, if set, is shown when an empty body is submitted, instead
+	// of submitting it
+	emptyBodyMsg string
+	// onSubmit returns the confirmation prompt to show for body, the message
+	// to show while the action runs, and the action itself
+	onSubmit func(body string) (string, string, tea.Cmd)
+}
+
+// startComposing opens the compose view.
+func (m *Model) startComposing(c composer, placeholder string) tea.Cmd {
+	m.composer = &c
+	m.composeTA.Reset()
+	m.composeTA.Placeholder = placeholder
+
+	if m.activePane == prDetailsView {
+		m.secondLastActivePane = m.lastPane
+	}
+	m.lastPane = m.activePane
+	m.activePane = composeView
+
+	return m.composeTA.Focus()
+}
+
+func (m *Model) stopComposing() {
+	m.composeTA.Blur()
+	m.composer = nil
+	m.activePane = m.lastPane
+}
+
+func (m Model) updateComposeView(msg tea.KeyPressMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc", "ctrl+c":
+		m.stopComposing()
+		return m, nil
+	case "ctrl+s":
+		body := strings.TrimSpace(m.composeTA.Value())
+		if body == "" && m.composer.emptyBodyMsg != "" {
+			m.message = m.composer.emptyBodyMsg
+			return m, nil
+		}
+
+		prompt, progressMsg, action := m.composer.onSubmit(body)
+		m.askForConfirmation(prompt, progressMsg, action)
+		return m, nil
+	}
+
+	var cmd tea.Cmd
+	m.composeTA, cmd = m.composeTA.Update(msg)
+	return m, cmd
+}
+
+func (m Model) getComposeTitle() string {
+	if m.composer == nil {
+		return ""
+	}
+	return m.composer.title
+}
@@ -0,0 +1,46 @@
+package ui
+
+import tea "charm.land/bubbletea/v2"
+
+// confirmation is a prompt shown in the status bar; the next key press is
+// handed to onAnswer.
+type confirmation struct {
+	prompt   string
+	options  string
+	onAnswer func(m *Model, key string) tea.Cmd
+}
+
+// askForConfirmation sets up action to run only once the user confirms it.
+func (m *Model) askForConfirmation(prompt, progressMsg string, action tea.Cmd) {
+	m.confirmation = &confirmation{
+		prompt:  prompt,
+		options: "y/n",
+		onAnswer: func(m *Model, key string) tea.Cmd {
+			if key != "y" {
+				m.message = "cancelled"
+				return nil
+			}
+
+			m.message = progressMsg
+			return action
+		},
+	}
+}
+
+// askForChoice prompts the user to pick one of several options, each
+// represented by a key.
+func (m *Model) askForChoice(prompt, options string, onAnswer func(m *Model, key string) tea.Cmd) {
+	m.confirmation = &confirmation{
+		prompt:   prompt,
+		options:  options,
+		onAnswer: onAnswer,
+	}
+}
+
+// handleConfirmation hands the key press to the pending prompt.
+func (m *Model) handleConfirmation(msg tea.KeyPressMsg) tea.Cmd {
+	c := m.confirmation
+	m.confirmation = nil
+
+	return c.onAnswer(m, msg.String())
+}
@@ -0,0 +1,191 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	ghapi "github.com/cli/go-gh/v2/pkg/api"
+)
+
+const (
+	diffMediaType     = "application/vnd.github.v3.diff"
+	diffClientTimeout = 30 * time.Second
+)
+
+var errCouldntFetchDiff = errors.New("couldn't fetch diff")
+
+type restRequester interface {
+	Request(method string, path string, body io.Reader) (*http.Response, error)
+}
+
+// newDiffClient returns a REST client that requests diffs from host; an empty
+// host uses the default host.
+func newDiffClient(host string) (*ghapi.RESTClient, error) {
+	return ghapi.NewRESTClient(ghapi.ClientOptions{
+		Host:    host,
+		Headers: map[string]string{"Accept": diffMediaType},
+		Timeout: diffClientTimeout,
+	})
+}
+
+// getPRDiff fetches a PR's diff via the REST API, for when gh is not
+// available to do that.
+func getPRDiff(client restRequester, repoOwner, repoName string, prNumber int) (string, error) {
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d", url.PathEscape(repoOwner), url.PathEscape(repoName), prNumber)
+	resp, err := client.Request(http.MethodGet, path, nil)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errCouldntFetchDiff, err.Error())
+	}
+	defer resp.Body.Close()
+
+	diff, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %s", errCouldntFetchDiff, err.Error())
+	}
+
+	return string(diff), nil
+}
+
+// diffFile marks the line in a diff where a file's changes begin.
+type diffFile struct {
+	path string
+	line int
+}
+
+type prDiffCacheEntry struct {
+	diff      string
+	updatedAt time.Time
+}
+
+// parseDiffFiles returns the files in a unified diff, in the order they
+// appear, along with the line each one starts at.
+func parseDiffFiles(diff string) []diffFile {
+	var files []diffFile
+	for i, line := range strings.Split(diff, "\n") {
+		if !strings.HasPrefix(line, "diff --git ") {
+			continue
+		}
+
+		// diff --git a/path b/path
+		_, path, found := strings.Cut(line, " b/")
+		if !found {
+			continue
+		}
+		files = append(files, diffFile{path: path, line: i})
+	}
+
+	return files
+}
+
+// orderDiffFiles orders the files in a diff as per the files list fetched
+// for the PR's details; files not present in that list are placed at the end.
+func orderDiffFiles(diffFiles []diffFile, prFiles []string) []diffFile {
+	byPath := make(map[string]diffFile, len(diffFiles))
+	for _, f := range diffFiles {
+		byPath[f.path] = f
+	}
+
+	ordered := make([]diffFile, 0, len(diffFiles))
+	seen := make(map[string]bool, len(diffFiles))
+	for _, path := range prFiles {
+		f, ok := byPath[path]
+		if !ok || seen[path] {
+			continue
+		}
+		ordered = append(ordered, f)
+		seen[path] = true
+	}
+
+	for _, f := range diffFiles {
+		if !seen[f.path] {
+			ordered = append(ordered, f)
+		}
+	}
+
+	return ordered
+}
+
+func renderDiff(diff string) string {
+	lines := strings.Split(strings.ReplaceAll(diff, "\t", "    "), "\n")
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "diff --git "):
+			lines[i] = diffFileHeaderStyle.Render(line)
+		case strings.HasPrefix(line, "+++ "), strings.HasPrefix(line, "--- "):
+			lines[i] = diffMetaStyle.Render(line)
+		case strings.HasPrefix(line, "@@"):
+			lines[i] = diffHunkStyle.Render(line)
+		case strings.HasPrefix(line, "+"):
+			lines[i] = diffAdditionStyle.Render(line)
+		case strings.HasPrefix(line, "-"):
+			lines[i] = diffDeletionStyle.Render(line)
+		case strings.HasPrefix(line, "index "),
+			strings.HasPrefix(line, "new file mode"),
+			strings.HasPrefix(line, "deleted file mode"),
+			strings.HasPrefix(line, "similarity index"),
+			strings.HasPrefix(line, "rename "),
+			strings.HasPrefix(line, "Binary files"),
+			strings.HasPrefix(line, `\ No newline`):
+			lines[i] = diffMetaStyle.Render(line)
+		}
+	}
+
+	return strings.Join(lines, "\n")
+}
+
+func (m *Model) showPRDiff(prRes *prResult, diff string) {
+	var prFiles []string
+	if prDetails, ok := m.prDetailsCache[prRes.identifier]; ok {
+		for _, f := range prDetails.Files.Nodes {
+			prFiles = append(prFiles, f.Path)
+		}
+	}
+
+	m.prDiffFiles = orderDiffFiles(parseDiffFiles(diff), prFiles)
+	m.prDiffTitle = fmt.Sprintf("#%d diff (%d files)", prRes.pr.Number, len(m.prDiffFiles))
+	m.prDiffVP.SetContent(renderDiff(diff))
+	m.prDiffVP.GotoTop()
+
+	if m.activePane != prDiffView {
+		m.lastPane = m.activePane
+	}
+	m.activePane = prDiffView
+}
+
+// goToDiffFile jumps to the file after (or before) the one being viewed, in
+// the order of the PR's files list; the file being viewed is the last one to
+// start at or above the top of the viewport, so scrolling is accounted for.
+func (m *Model) goToDiffFile(next bool) {
+	if len(m.prDiffFiles) == 0 {
+		return
+	}
+
+	offset := m.prDiffVP.YOffset()
+	current := -1
+	for i, f := range m.prDiffFiles {
+		if f.line <= offset && (current == -1 || f.line > m.prDiffFiles[current].line) {
+			current = i
+		}
+	}
+
+	var index int
+	switch {
+	case current == -1 && next:
+		index = 0
+	case current == -1:
+		index = len(m.prDiffFiles) - 1
+	case next:
+		index = (current + 1) % len(m.prDiffFiles)
+	default:
+		index = (current - 1 + len(m.prDiffFiles)) % len(m.prDiffFiles)
+	}
+
+	target := m.prDiffFiles[index]
+	m.prDiffVP.SetYOffset(target.line)
+	m.message = fmt.Sprintf("file %d/%d: %s", index+1, len(m.prDiffFiles), target.path)
+}
@@ -0,0 +1,109 @@
+package ui
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRESTClient struct {
+	method  string
+	path    string
+	reqBody string
+	body    string
+	err     error
+}
+
+func (c *fakeRESTClient) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	c.method = method
+	c.path = path
+	if body != nil {
+		reqBody, _ := io.ReadAll(body)
+		c.reqBody = string(reqBody)
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Body:       io.NopCloser(strings.NewReader(c.body)),
+	}, nil
+}
+
+func TestGetPRDiff(t *testing.T) {
+	client := &fakeRESTClient{body: "diff --git a/main.go b/main.go\n"}
+
+	diff, err := getPRDiff(client, "dhth", "prs", 42)
+	require.NoError(t, err)
+	assert.Equal(t, "repos/dhth/prs/pulls/42", client.path)
+	assert.Equal(t, "diff --git a/main.go b/main.go\n", diff)
+}
+
+func TestGetPRDiffReturnsErrorOnFailedRequest(t *testing.T) {
+	client := &fakeRESTClient{err: io.ErrUnexpectedEOF}
+
+	_, err := getPRDiff(client, "dhth", "prs", 42)
+	assert.ErrorIs(t, err, errCouldntFetchDiff)
+}
+
+const testDiff = `diff --git a/README.md b/README.md
+index 1111111..2222222 100644
+--- a/README.md
++++ b/README.md
+@@ -1,2 +1,2 @@
+-old
++new
+diff --git a/ui/diff.go b/ui/diff.go
+new file mode 100644
+--- /dev/null
++++ b/ui/diff.go
+@@ -0,0 +1 @@
++package ui
+`
+
+func TestParseDiffFiles(t *testing.T) {
+	files := parseDiffFiles(testDiff)
+
+	assert.Equal(t, []diffFile{
+		{path: "README.md", line: 0},
+		{path: "ui/diff.go", line: 7},
+	}, files)
+}
+
+func TestOrderDiffFilesFollowsPRFiles(t *testing.T) {
+	files := parseDiffFiles(testDiff)
+
+	ordered := orderDiffFiles(files, []string{"ui/diff.go", "missing.go"})
+
+	assert.Equal(t, []diffFile{
+		{path: "ui/diff.go", line: 7},
+		{path: "README.md", line: 0},
+	}, ordered)
+}
+
+func TestGoToDiffFileFollowsPRFiles(t *testing.T) {
+	query := "type:pr author:@me"
+	m := InitialModel(&fakePRSource{}, Config{Query: &query}, QueryMode)
+	m.prDiffVP.SetHeight(2)
+
+	prRes := &prResult{pr: &pr{Number: 1}, identifier: "github.com/dhth/prs:1"}
+	m.prDetailsCache[prRes.identifier] = prDetails{Files: getTestPRFiles("ui/diff.go", "README.md")}
+	m.showPRDiff(prRes, testDiff)
+
+	// the diff opens on README.md, which comes after ui/diff.go in the PR's
+	// files list
+	m.goToDiffFile(true)
+	assert.Equal(t, "file 1/2: ui/diff.go", m.message)
+	assert.Equal(t, 7, m.prDiffVP.YOffset())
+
+	m.goToDiffFile(true)
+	assert.Equal(t, "file 2/2: README.md", m.message)
+	assert.Equal(t, 0, m.prDiffVP.YOffset())
+
+	m.goToDiffFile(false)
+	assert.Equal(t, "file 1/2: ui/diff.go", m.message)
+}
@@ -1,22 +1,24 @@
 package ui
 
 import (
+	"fmt"
 	"log"
+	"reflect"
 
-	ghapi "github.com/cli/go-gh/v2/pkg/api"
 	ghgql "github.com/cli/shurcooL-graphql"
 )
 
-func getPRDataFromQuery(ghClient *ghapi.GraphQLClient, queryStr string, prCount int) ([]pr, error) {
+func getPRDataFromQuery(ghClient graphQLQuerier, queryStr string, prCount int, after *string) ([]pr, pageInfo, error) {
 	var query prSearchQuery
 
 	variables := map[string]any{
 		"query": ghgql.String(queryStr),
 		"count": ghgql.Int(prCount),
+		"after": (*ghgql.String)(after),
 	}
 	err := ghClient.Query("PRQuery", &query, variables)
 	if err != nil {
-		return nil, err
+		return nil, pageInfo{}, err
 	}
 	var prs []pr //nolint:prealloc
 	for _, edge := range query.Search.Edges {
@@ -25,16 +27,11 @@ func getPRDataFromQuery(ghClient *ghapi.GraphQLClient, queryStr string, prCount
 		}
 		prs = append(prs, edge.Node.pr)
 	}
-	return prs, nil
+	return prs, query.Search.PageInfo, nil
 }
 
-func getPRMetadata(ghClient *ghapi.GraphQLClient, repoOwner string, repoName string, prNumber int) (prDetails, error) {
-	var query prDetailsQuery
-
-	variables := map[string]any{
-		"repositoryOwner":          ghgql.String(repoOwner),
-		"repositoryName":           ghgql.String(repoName),
-		"pullRequestNumber":        ghgql.Int(prNumber),
+func getPRDetailsVariables() map[string]any {
+	return map[string]any{
 		"reviewRequestsCount":      ghgql.Int(reviewRequestsCount),
 		"latestReviewsCount":       ghgql.Int(latestReviewsCount),
 		"filesCount":               ghgql.Int(filesCount),
@@ -46,6 +43,16 @@ func getPRMetadata(ghClient *ghapi.GraphQLClient, repoOwner string, repoName str
 		"commitsCount":             ghgql.Int(commitsCount),
 		"statusCheckContextsCount": ghgql.Int(statusCheckContextsCount),
 	}
+}
+
+func getPRMetadata(ghClient graphQLQuerier, repoOwner string, repoName string, prNumber int) (prDetails, error) {
+	var query prDetailsQuery
+
+	variables := getPRDetailsVariables()
+	variables["repositoryOwner"] = ghgql.String(repoOwner)
+	variables["repositoryName"] = ghgql.String(repoName)
+	variables["pullRequestNumber"] = ghgql.Int(prNumber)
+
 	err := ghClient.Query("PRTL", &query, variables)
 	if err != nil {
 		log.Printf("error: %s\n", err)
@@ -54,18 +61,297 @@ func getPRMetadata(ghClient *ghapi.GraphQLClient, repoOwner string, repoName str
 	return query.RepositoryOwner.Repository.PullRequest, nil
 }
 
-func getPRTLData(ghClient *ghapi.GraphQLClient, repoOwner string, repoName string, prNumber int, tlItemsCount int) ([]prTLItem, error) {
+func getPRTLData(ghClient graphQLQuerier, repoOwner string, repoName string, prNumber int, tlItemsCount int, before *string) ([]prTLItem, tlPageInfo, error) {
 	var query prTLQuery
 
 	variables := map[string]any{
-		"repositoryOwner":    ghgql.String(repoOwner),
-		"repositoryName":     ghgql.String(repoName),
-		"pullRequestNumber":  ghgql.Int(prNumber),
-		"timelineItemsCount": ghgql.Int(tlItemsCount),
+		"repositoryOwner":     ghgql.String(repoOwner),
+		"repositoryName":      ghgql.String(repoName),
+		"pullRequestNumber":   ghgql.Int(prNumber),
+		"timelineItemsCount":  ghgql.Int(tlItemsCount),
+		"timelineItemsBefore": (*ghgql.String)(before),
 	}
 	err := ghClient.Query("PRTL", &query, variables)
+	if err != nil {
+		return nil, tlPageInfo{}, err
+	}
+	timelineItems := query.RepositoryOwner.Repository.PullRequest.TimelineItems
+	return timelineItems.Nodes, timelineItems.PageInfo, nil
+}
+
+func getPRFiles(ghClient graphQLQuerier, repoOwner string, repoName string, prNumber int, after *string) (prFiles, error) {
+	var query prFilesQuery
+
+	variables := map[string]any{
+		"repositoryOwner":   ghgql.String(repoOwner),
+		"repositoryName":    ghgql.String(repoName),
+		"pullRequestNumber": ghgql.Int(prNumber),
+		"filesCount":        ghgql.Int(morePRDetailsCount),
+		"filesAfter":        (*ghgql.String)(after),
+	}
+	err := ghClient.Query("PRFiles", &query, variables)
+	if err != nil {
+		return prFiles{}, err
+	}
+	return query.RepositoryOwner.Repository.PullRequest.Files, nil
+}
+
+func getPRCommits(ghClient graphQLQuerier, repoOwner string, repoName string, prNumber int, before *string) (prCommits, error) {
+	var query prCommitsQuery
+
+	variables := map[string]any{
+		"repositoryOwner":   ghgql.String(repoOwner),
+		"repositoryName":    ghgql.String(repoName),
+		"pullRequestNumber": ghgql.Int(prNumber),
+		"commitsCount":      ghgql.Int(morePRDetailsCount),
+		"commitsBefore":     (*ghgql.String)(before),
+	}
+	err := ghClient.Query("PRCommits", &query, variables)
+	if err != nil {
+		return prCommits{}, err
+	}
+	return query.RepositoryOwner.Repository.PullRequest.Commits, nil
+}
+
+func getPRComments(ghClient graphQLQuerier, repoOwner string, repoName string, prNumber int, after *string) (prComments, error) {
+	var query prCommentsQuery
+
+	variables := map[string]any{
+		"repositoryOwner":   ghgql.String(repoOwner),
+		"repositoryName":    ghgql.String(repoName),
+		"pullRequestNumber": ghgql.Int(prNumber),
+		"commentsCount":     ghgql.Int(morePRDetailsCount),
+		"commentsAfter":     (*ghgql.String)(after),
+	}
+	err := ghClient.Query("PRComments", &query, variables)
+	if err != nil {
+		return prComments{}, err
+	}
+	return query.RepositoryOwner.Repository.PullRequest.Comments, nil
+}
+
+// getPRsBatchData fetches details and timelines for several PRs in a single
+// query. GraphQL needs a distinct alias (and variables) for every PR, which
+// can't be expressed with a static struct, so the query type is built at
+// runtime.
+func getPRsBatchData(ghClient graphQLQuerier, prs []prRef, tlItemsCount int) ([]prData, error) {
+	variables := getPRDetailsVariables()
+	variables["timelineItemsCount"] = ghgql.Int(tlItemsCount)
+	variables["timelineItemsBefore"] = (*ghgql.String)(nil)
+
+	fields := make([]reflect.StructField, len(prs), len(prs)+1)
+	for i, p := range prs {
+		prType := reflect.StructOf([]reflect.StructField{
+			{
+				Name: "Details",
+				Type: reflect.TypeFor[prDetails](),
+				Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"details: pullRequest(number: $pullRequestNumber%d)"`, i)),
+			},
+			{
+				Name: "Timeline",
+				Type: reflect.TypeFor[prTimeline](),
+				Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"timeline: pullRequest(number: $pullRequestNumber%d)"`, i)),
+			},
+		})
+		fields[i] = reflect.StructField{
+			Name: fmt.Sprintf("PR%d", i),
+			Type: prType,
+			Tag:  reflect.StructTag(fmt.Sprintf(`graphql:"pr%d: repository(owner: $repositoryOwner%d, name: $repositoryName%d)"`, i, i, i)),
+		}
+
+		variables[fmt.Sprintf("repositoryOwner%d", i)] = ghgql.String(p.repoOwner)
+		variables[fmt.Sprintf("repositoryName%d", i)] = ghgql.String(p.repoName)
+		variables[fmt.Sprintf("pullRequestNumber%d", i)] = ghgql.Int(p.prNumber)
+	}
+
+	fields = append(fields, reflect.StructField{
+		Name: "RateLimit",
+		Type: reflect.TypeFor[rateLimit](),
+	})
+
+	query := reflect.New(reflect.StructOf(fields))
+	err := ghClient.Query("PRsBatch", query.Interface(), variables)
 	if err != nil {
 		return nil, err
 	}
-	return query.RepositoryOwner.Repository.PullRequest.TimelineItems.Nodes, nil
+
+	results := make([]prData, len(prs))
+	for i := range prs {
+		prVal := query.Elem().Field(i)
+		timelineItems := prVal.Field(1).Interface().(prTimeline).TimelineItems
+		results[i] = prData{
+			details:    prVal.Field(0).Interface().(prDetails),
+			tlItems:    timelineItems.Nodes,
+			tlPageInfo: timelineItems.PageInfo,
+		}
+	}
+
+	return results, nil
+}
+
+// submitPRReview adds a review to a PR, and submits it in the same request,
+// so that a failure can't leave a pending review behind.
+func submitPRReview(ghClient graphQLQuerier, prID string, event PullRequestReviewEvent, body string) (string, error) {
+	var mutation addPRReviewMutation
+	err := ghClient.Mutate("AddPRReview", &mutation, map[string]any{
+		"pullRequestId": ghgql.ID(prID),
+		"event":         event,
+		"body":          ghgql.String(body),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	return mutation.AddPullRequestReview.PullRequestReview.State, nil
+}
+
+func mergePR(ghClient graphQLQuerier, prID string, method PullRequestMergeMethod) error {
+	var mutation mergePRMutation
+	return ghClient.Mutate("MergePR", &mutation, map[string]any{
+		"pullRequestId": ghgql.ID(prID),
+		"mergeMethod":   method,
+	})
+}
+
+func enablePRAutoMerge(ghClient graphQLQuerier, prID string, method PullRequestMergeMethod) error {
+	var mutation enablePRAutoMergeMutation
+	return ghClient.Mutate("EnablePRAutoMerge", &mutation, map[string]any{
+		"pullRequestId": ghgql.ID(prID),
+		"mergeMethod":   method,
+	})
+}
+
+// getPRReviewThreads pages through all of a PR's review threads, as review
+// comments are looked up in them; the comments in a thread aren't paged.
+func getPRReviewThreads(ghClient graphQLQuerier, repoOwner string, repoName string, prNumber int) ([]prReviewThread, error) {
+	var threads []prReviewThread
+	var after *string
+	for {
+		var query prReviewThreadsQuery
+
+		variables := map[string]any{
+			"repositoryOwner":     ghgql.String(repoOwner),
+			"repositoryName":      ghgql.String(repoName),
+			"pullRequestNumber":   ghgql.Int(prNumber),
+			"reviewThreadsCount":  ghgql.Int(reviewThreadsCount),
+			"reviewThreadsAfter":  (*ghgql.String)(after),
+			"threadCommentsCount": ghgql.Int(threadCommentsCount),
+		}
+		err := ghClient.Query("PRReviewThreads", &query, variables)
+		if err != nil {
+			return nil, err
+		}
+
+		page := query.RepositoryOwner.Repository.PullRequest.ReviewThreads
+		threads = append(threads, page.Nodes...)
+		if !page.PageInfo.HasNextPage || page.PageInfo.EndCursor == nil {
+			return threads, nil
+		}
+		after = page.PageInfo.EndCursor
+	}
+}
+
+func getCheckRun(ghClient graphQLQuerier, checkRunID string) (checkRun, error) {
+	var query checkRunQuery
+
+	variables := map[string]any{
+		"checkRunId":       ghgql.ID(checkRunID),
+		"annotationsCount": ghgql.Int(checkAnnotationsCount),
+	}
+	err := ghClient.Query("CheckRun", &query, variables)
+	if err != nil {
+		return checkRun{}, err
+	}
+
+	return query.Node.CheckRun, nil
+}
+
+func rerequestCheckSuite(ghClient graphQLQuerier, repoID string, checkSuiteID string) error {
+	var mutation rerequestCheckSuiteMutation
+	return ghClient.Mutate("RerequestCheckSuite", &mutation, map[string]any{
+		"repositoryId": ghgql.ID(repoID),
+		"checkSuiteId": ghgql.ID(checkSuiteID),
+	})
+}
+
+func replyToReviewThread(ghClient graphQLQuerier, threadID string, body string) error {
+	var mutation replyToReviewThreadMutation
+	return ghClient.Mutate("ReplyToReviewThread", &mutation, map[string]any{
+		"threadId": ghgql.ID(threadID),
+		"body":     ghgql.String(body),
+	})
+}
+
+func setReviewThreadResolved(ghClient graphQLQuerier, threadID string, resolved bool) error {
+	variables := map[string]any{
+		"threadId": ghgql.ID(threadID),
+	}
+
+	if resolved {
+		var mutation resolveReviewThreadMutation
+		return ghClient.Mutate("ResolveReviewThread", &mutation, variables)
+	}
+
+	var mutation unresolveReviewThreadMutation
+	return ghClient.Mutate("UnresolveReviewThread", &mutation, variables)
+}
+
+func getRepoMetadataOptions(ghClient graphQLQuerier, repoOwner string, repoName string) (repoMetadataOptions, error) {
+	var query repoMetadataOptionsQuery
+
+	variables := map[string]any{
+		"repositoryOwner":      ghgql.String(repoOwner),
+		"repositoryName":       ghgql.String(repoName),
+		"metadataOptionsCount": ghgql.Int(metadataOptionsCount),
+	}
+	err := ghClient.Query("RepoMetadataOptions", &query, variables)
+	if err != nil {
+		return repoMetadataOptions{}, err
+	}
+
+	return query.RepositoryOwner.Repository, nil
+}
+
+func editPRMetadata(ghClient graphQLQuerier, prID string, edit prMetadataEdit) error {
+	variables := map[string]any{
+		"pullRequestId": ghgql.ID(prID),
+	}
+
+	switch edit.kind {
+	case labelsMetadata:
+		variables["ids"] = []ghgql.ID{ghgql.ID(edit.option.id)}
+		if edit.add {
+			var mutation addLabelsMutation
+			return ghClient.Mutate("AddLabels", &mutation, variables)
+		}
+		var mutation removeLabelsMutation
+		return ghClient.Mutate("RemoveLabels", &mutation, variables)
+
+	case assigneesMetadata:
+		variables["ids"] = []ghgql.ID{ghgql.ID(edit.option.id)}
+		if edit.add {
+			var mutation addAssigneesMutation
+			return ghClient.Mutate("AddAssignees", &mutation, variables)
+		}
+		var mutation removeAssigneesMutation
+		return ghClient.Mutate("RemoveAssignees", &mutation, variables)
+
+	case reviewersMetadata:
+		if !edit.add {
+			return errReviewRequestsCantBeRemoved
+		}
+		variables["ids"] = []ghgql.ID{ghgql.ID(edit.option.id)}
+		var mutation requestReviewsMutation
+		return ghClient.Mutate("RequestReviews", &mutation, variables)
+
+	default:
+		var milestoneID *ghgql.ID
+		if edit.add {
+			id := ghgql.ID(edit.option.id)
+			milestoneID = &id
+		}
+		variables["milestoneId"] = milestoneID
+		var mutation setMilestoneMutation
+		return ghClient.Mutate("SetMilestone", &mutation, variables)
+	}
 }
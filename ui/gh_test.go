@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	ghapi "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func newTestGHClient(t *testing.T, handler func(query string, variables map[string]any) string) *ghapi.GraphQLClient {
+	t.Helper()
+
+	rt := roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		var body struct {
+			Query     string         `json:"query"`
+			Variables map[string]any `json:"variables"`
+		}
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			return nil, err
+		}
+
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(handler(body.Query, body.Variables))),
+			Request:    r,
+		}, nil
+	})
+
+	client, err := ghapi.NewGraphQLClient(ghapi.ClientOptions{
+		Host:         "github.com",
+		AuthToken:    "token",
+		Transport:    rt,
+		LogIgnoreEnv: true,
+	})
+	require.NoError(t, err)
+
+	return client
+}
+
+func TestGetPRsBatchData(t *testing.T) {
+	var gotQuery string
+	var gotVariables map[string]any
+	client := newTestGHClient(t, func(query string, variables map[string]any) string {
+		gotQuery = query
+		gotVariables = variables
+		return `{"data": {
+  "pr0": {"details": {"number": 1, "prTitle": "first"}, "timeline": {"timelineItems": {"nodes": [{"type": "MergedEvent"}]}}},
+  "pr1": {"details": {"number": 7, "prTitle": "second"}, "timeline": {"timelineItems": {"nodes": []}}}
+}}`
+	})
+
+	got, err := getPRsBatchData(client, []prRef{{repoOwner: "dhth", repoName: "prs", prNumber: 1}, {repoOwner: "dhth", repoName: "omm", prNumber: 7}}, 100)
+	require.NoError(t, err)
+
+	assert.Contains(t, gotQuery, "pr0: repository(owner: $repositoryOwner0, name: $repositoryName0)")
+	assert.Contains(t, gotQuery, "pr1: repository(owner: $repositoryOwner1, name: $repositoryName1)")
+	assert.Equal(t, "omm", gotVariables["repositoryName1"])
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "first", got[0].details.PRTitle)
+	require.Len(t, got[0].tlItems, 1)
+	assert.Equal(t, tlItemMergedEvent, got[0].tlItems[0].Type)
+	assert.Equal(t, 7, got[1].details.Number)
+}
+
+func TestSubmitPRReview(t *testing.T) {
+	var queries []string
+	var variables map[string]any
+	client := newTestGHClient(t, func(query string, vars map[string]any) string {
+		queries = append(queries, query)
+		variables = vars
+		return `{"data": {"addPullRequestReview": {"pullRequestReview": {"id": "PRR_1", "state": "CHANGES_REQUESTED"}}}}`
+	})
+
+	state, err := submitPRReview(client, "PR_1", reviewRequestChanges, "needs tests")
+	require.NoError(t, err)
+
+	// the review is submitted as it's added, so there's no pending review to
+	// clean up if something fails
+	require.Len(t, queries, 1)
+	assert.Contains(t, queries[0], "$event:PullRequestReviewEvent!")
+	assert.Equal(t, "PR_1", variables["pullRequestId"])
+	assert.Equal(t, "REQUEST_CHANGES", variables["event"])
+	assert.Equal(t, "needs tests", variables["body"])
+	assert.Equal(t, "CHANGES_REQUESTED", state)
+}
+
+func TestGetPRReviewThreads(t *testing.T) {
+	var afters []any
+	client := newTestGHClient(t, func(_ string, variables map[string]any) string {
+		afters = append(afters, variables["reviewThreadsAfter"])
+		if variables["reviewThreadsAfter"] == nil {
+			return `{"data": {"repositoryOwner": {"repository": {"pullRequest": {"reviewThreads": {
+  "pageInfo": {"hasNextPage": true, "endCursor": "Y3Vyc29yOjE="},
+  "nodes": [
+    {"id": "PRRT_1", "isResolved": true, "path": "ui/gh.go", "comments": {"totalCount": 2, "nodes": [
+      {"id": "C_1", "body": "nit", "author": {"login": "dhth"}},
+      {"id": "C_2", "body": "fixed", "author": {"login": "other"}}
+    ]}}
+  ]
+}}}}}}`
+		}
+		return `{"data": {"repositoryOwner": {"repository": {"pullRequest": {"reviewThreads": {
+  "pageInfo": {"hasNextPage": false, "endCursor": "Y3Vyc29yOjI="},
+  "nodes": [{"id": "PRRT_2", "path": "ui/gh.go", "comments": {"totalCount": 1, "nodes": [{"id": "C_3"}]}}]
+}}}}}}`
+	})
+
+	got, err := getPRReviewThreads(client, "dhth", "prs", 1)
+	require.NoError(t, err)
+
+	assert.Equal(t, []any{nil, "Y3Vyc29yOjE="}, afters)
+	require.Len(t, got, 2)
+	assert.Equal(t, "PRRT_1", got[0].ID)
+	assert.Equal(t, "PRRT_2", got[1].ID)
+	assert.True(t, got[0].IsResolved)
+	require.Len(t, got[0].Comments.Nodes, 2)
+	assert.Equal(t, "other", got[0].Comments.Nodes[1].Author.Login)
+}
+
+func TestGetPRCommitsPagesBackwards(t *testing.T) {
+	var gotVariables map[string]any
+	client := newTestGHClient(t, func(_ string, variables map[string]any) string {
+		gotVariables = variables
+		return `{"data": {"repositoryOwner": {"repository": {"pullRequest": {"commits": {
+  "totalCount": 130,
+  "pageInfo": {"hasPreviousPage": false, "startCursor": "Y3Vyc29yOjE="},
+  "nodes": [{"commit": {"abbreviatedOid": "abc1234", "messageHeadline": "first"}}]
+}}}}}}`
+	})
+
+	cursor := "Y3Vyc29yOjMx"
+	got, err := getPRCommits(client, "dhth", "prs", 1, &cursor)
+	require.NoError(t, err)
+
+	assert.Equal(t, cursor, gotVariables["commitsBefore"])
+	assert.EqualValues(t, morePRDetailsCount, gotVariables["commitsCount"])
+	assert.Equal(t, 130, got.TotalCount)
+	assert.False(t, got.PageInfo.HasPreviousPage)
+	require.Len(t, got.Nodes, 1)
+	assert.Equal(t, "abc1234", got.Nodes[0].Commit.AbbreviatedOid)
+}
+
+func TestGetCheckRun(t *testing.T) {
+	var gotQuery string
+	var gotVariables map[string]any
+	client := newTestGHClient(t, func(query string, variables map[string]any) string {
+		gotQuery = query
+		gotVariables = variables
+		return `{"data": {"node": {"id": "CR_1", "databaseId": 123, "name": "build", "status": "COMPLETED", "conclusion": "FAILURE",
+  "detailsUrl": "https://github.com/dhth/prs/actions/runs/1/job/123", "checkSuite": {"app": {"slug": "github-actions"}},
+  "annotations": {"totalCount": 1, "nodes": [
+    {"path": "ui/gh.go", "location": {"start": {"line": 4}, "end": {"line": 4}}, "annotationLevel": "FAILURE", "message": "unused import"}
+  ]}}}}`
+	})
+
+	got, err := getCheckRun(client, "CR_1")
+	require.NoError(t, err)
+
+	assert.Contains(t, gotQuery, "databaseId")
+	assert.Contains(t, gotQuery, "detailsUrl")
+	assert.Equal(t, "CR_1", gotVariables["checkRunId"])
+	require.NotNil(t, got.DatabaseID)
+	assert.Equal(t, 123, *got.DatabaseID)
+	require.NotNil(t, got.CheckSuite.App)
+	assert.Equal(t, githubActionsAppSlug, got.CheckSuite.App.Slug)
+	require.Len(t, got.Annotations.Nodes, 1)
+	assert.Equal(t, 4, got.Annotations.Nodes[0].Location.Start.Line)
+}
+
+func TestRerequestCheckSuite(t *testing.T) {
+	var gotQuery string
+	var gotVariables map[string]any
+	client := newTestGHClient(t, func(query string, variables map[string]any) string {
+		gotQuery = query
+		gotVariables = variables
+		return `{"data": {"rerequestCheckSuite": {"checkSuite": {"id": "CS_1"}}}}`
+	})
+
+	err := rerequestCheckSuite(client, "R_1", "CS_1")
+	require.NoError(t, err)
+
+	assert.Contains(t, gotQuery, "rerequestCheckSuite(input: {repositoryId: $repositoryId, checkSuiteId: $checkSuiteId})")
+	assert.Equal(t, "R_1", gotVariables["repositoryId"])
+	assert.Equal(t, "CS_1", gotVariables["checkSuiteId"])
+}
+
+func TestSetReviewThreadResolved(t *testing.T) {
+	var gotQuery string
+	var gotVariables map[string]any
+	client := newTestGHClient(t, func(query string, variables map[string]any) string {
+		gotQuery = query
+		gotVariables = variables
+		return `{"data": {"unresolveReviewThread": {"thread": {"isResolved": false}}}}`
+	})
+
+	err := setReviewThreadResolved(client, "PRRT_1", false)
+	require.NoError(t, err)
+
+	assert.Contains(t, gotQuery, "unresolveReviewThread(input: {threadId: $threadId})")
+	assert.Equal(t, "PRRT_1", gotVariables["threadId"])
+}
+
+func TestEditPRMetadataAddsReviewers(t *testing.T) {
+	var gotQuery string
+	var gotVariables map[string]any
+	client := newTestGHClient(t, func(query string, variables map[string]any) string {
+		gotQuery = query
+		gotVariables = variables
+		return `{"data": {"requestReviews": {"clientMutationId": null}}}`
+	})
+
+	err := editPRMetadata(client, "PR_1", prMetadataEdit{
+		kind:   reviewersMetadata,
+		option: metadataOption{"U_2", "other"},
+		add:    true,
+	})
+	require.NoError(t, err)
+
+	// the reviewers requested already are left alone
+	assert.Contains(t, gotQuery, "union: true")
+	assert.Equal(t, []any{"U_2"}, gotVariables["ids"])
+
+	err = editPRMetadata(client, "PR_1", prMetadataEdit{
+		kind:   reviewersMetadata,
+		option: metadataOption{"U_2", "other"},
+	})
+	assert.ErrorIs(t, err, errReviewRequestsCantBeRemoved)
+}
+
+func TestGetPRTLDataPagesBackwards(t *testing.T) {
+	var gotQuery string
+	var gotVariables map[string]any
+	client := newTestGHClient(t, func(query string, variables map[string]any) string {
+		gotQuery = query
+		gotVariables = variables
+		return `{"data": {"repositoryOwner": {"repository": {"pullRequest": {"timelineItems": {
+  "pageInfo": {"hasPreviousPage": true, "startCursor": "Y3Vyc29yOjE="},
+  "nodes": [{"type": "IssueComment", "body": "looks good", "author": {"login": "octocat"}}]
+}}}}}}`
+	})
+
+	before := "Y3Vyc29yOjEwMQ=="
+	items, pageInfo, err := getPRTLData(client, "dhth", "prs", 1, 100, &before)
+	require.NoError(t, err)
+
+	assert.Contains(t, gotQuery, "$timelineItemsBefore:String")
+	assert.Contains(t, gotQuery, "before: $timelineItemsBefore")
+	assert.Contains(t, gotQuery, "ISSUE_COMMENT")
+	assert.Equal(t, before, gotVariables["timelineItemsBefore"])
+
+	require.Len(t, items, 1)
+	assert.Equal(t, tlItemIssueComment, items[0].Type)
+	assert.Equal(t, "octocat", items[0].IssueComment.Author.Login)
+	assert.True(t, pageInfo.HasPreviousPage)
+	require.NotNil(t, pageInfo.StartCursor)
+	assert.Equal(t, "Y3Vyc29yOjE=", *pageInfo.StartCursor)
+}
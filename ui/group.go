@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+
+	"charm.land/bubbles/v2/list"
+)
+
+const (
+	collapsedGroupMarker = "▸"
+	expandedGroupMarker  = "▾"
+)
+
+// prGrouping is how PRs are grouped under headers in the PR list.
+type prGrouping uint
+
+const (
+	noGrouping prGrouping = iota
+	groupByRepo
+	groupByAuthor
+	groupByReviewDecision
+	groupByDraft
+	prGroupingsCount
+)
+
+func (g prGrouping) label() string {
+	switch g {
+	case groupByRepo:
+		return "repo"
+	case groupByAuthor:
+		return "author"
+	case groupByReviewDecision:
+		return "review decision"
+	case groupByDraft:
+		return "draft status"
+	default:
+		return "none"
+	}
+}
+
+func (g prGrouping) next() prGrouping {
+	return (g + 1) % prGroupingsCount
+}
+
+// groupOf returns the name of the group p belongs to, and how that group is
+// ranked against others; groups with the same rank are shown in the order
+// their first PR appears in.
+func (g prGrouping) groupOf(p *pr) (string, int) {
+	switch g {
+	case groupByRepo:
+		return fmt.Sprintf("%s/%s", p.Repository.Owner.Login, p.Repository.Name), 0
+	case groupByAuthor:
+		return p.Author.Login, 0
+	case groupByReviewDecision:
+		rank := reviewDecisionRank(p)
+		switch rank {
+		case 0:
+			return "changes requested", rank
+		case 1:
+			return "review required", rank
+		case 2:
+			return "approved", rank
+		default:
+			return "no review decision", rank
+		}
+	case groupByDraft:
+		if p.IsDraft {
+			return "draft", 1
+		}
+		return "ready for review", 0
+	default:
+		return "", 0
+	}
+}
+
+// prGroupHeader precedes the PRs of a group in the PR list; the group's PRs
+// are left out while it's collapsed.
+type prGroupHeader struct {
+	name      string
+	numPRs    int
+	collapsed bool
+	// dynamic is set for groups named after a repo or a user, which get a
+	// colour of their own
+	dynamic bool
+}
+
+func (h prGroupHeader) Title() string {
+	marker := expandedGroupMarker
+	if h.collapsed {
+		marker = collapsedGroupMarker
+	}
+	return fmt.Sprintf("%s %s", marker, h.name)
+}
+
+func (h prGroupHeader) Description() string {
+	if h.numPRs == 1 {
+		return "1 PR"
+	}
+	return fmt.Sprintf("%d PRs", h.numPRs)
+}
+
+// FilterValue is empty, as headers aren't shown while the PR list is
+// filtered.
+func (h prGroupHeader) FilterValue() string {
+	return ""
+}
+
+// getPRListItems returns the items to show in the PR list for prs, sorted and
+// grouped as chosen.
+func (m Model) getPRListItems(prs []*prResult) []list.Item {
+	sorted := sortPRResults(prs, m.prSort)
+	if m.prGrouping == noGrouping {
+		items := make([]list.Item, len(sorted))
+		for i, prRes := range sorted {
+			items[i] = prRes
+		}
+		return items
+	}
+
+	var names []string
+	ranks := make(map[string]int)
+	groups := make(map[string][]*prResult)
+	for _, prRes := range sorted {
+		name, rank := m.prGrouping.groupOf(prRes.pr)
+		if _, ok := groups[name]; !ok {
+			names = append(names, name)
+			ranks[name] = rank
+		}
+		groups[name] = append(groups[name], prRes)
+	}
+
+	slices.SortStableFunc(names, func(a, b string) int {
+		return cmp.Compare(ranks[a], ranks[b])
+	})
+
+	dynamic := m.prGrouping == groupByRepo || m.prGrouping == groupByAuthor
+	items := make([]list.Item, 0, len(sorted)+len(names))
+	for _, name := range names {
+		collapsed := m.collapsedPRGroups[name]
+		items = append(items, prGroupHeader{
+			name:      name,
+			numPRs:    len(groups[name]),
+			collapsed: collapsed,
+			dynamic:   dynamic,
+		})
+		if collapsed {
+			continue
+		}
+		for _, prRes := range groups[name] {
+			items = append(items, prRes)
+		}
+	}
+
+	return items
+}
+
+// cyclePRGrouping switches the PR list over to the next grouping.
+func (m *Model) cyclePRGrouping() {
+	m.prGrouping = m.prGrouping.next()
+	m.collapsedPRGroups = make(map[string]bool)
+	m.setPRListItems()
+
+	// the cursor might've been on a header that's no longer there
+	if m.prsList.Index() >= len(m.prsList.VisibleItems()) {
+		m.prsList.ResetSelected()
+	}
+
+	if !m.awaitingPRs && !m.fetchingMorePRs && !m.prsFromCache {
+		m.resetPRsListTitle()
+	}
+	m.message = "grouped by " + m.prGrouping.label()
+}
+
+// togglePRGroup collapses (or expands) the group whose header is under the
+// cursor, and reports whether there was such a header.
+func (m *Model) togglePRGroup() bool {
+	header, ok := m.prsList.SelectedItem().(prGroupHeader)
+	if !ok {
+		return false
+	}
+
+	if header.collapsed {
+		delete(m.collapsedPRGroups, header.name)
+	} else {
+		m.collapsedPRGroups[header.name] = true
+	}
+	m.setPRListItems()
+
+	return true
+}
+
+func (m Model) prGroupHeaderSelected() bool {
+	_, ok := m.prsList.SelectedItem().(prGroupHeader)
+	return ok
+}
+
+// movePRCursor moves the PR list's cursor to the previous (or next) PR,
+// skipping group headers; the cursor stays put if there's no such PR.
+func (m *Model) movePRCursor(up bool) {
+	index := m.prsList.Index()
+	items := m.prsList.VisibleItems()
+	for i := index; ; {
+		if up {
+			i--
+		} else {
+			i++
+		}
+		if i < 0 || i >= len(items) {
+			return
+		}
+		if _, ok := items[i].(*prResult); ok {
+			m.prsList.Select(i)
+			return
+		}
+	}
+}
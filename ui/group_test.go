@@ -0,0 +1,111 @@
+package ui
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPRListItemKeys(items []list.Item) []string {
+	keys := make([]string, len(items))
+	for i, item := range items {
+		keys[i] = getPRListItemKey(item)
+	}
+	return keys
+}
+
+func getGroupingTestModel(t *testing.T) Model {
+	t.Helper()
+
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := "type:pr author:@me"
+	m := InitialModel(&fakePRSource{}, Config{Query: &query}, QueryMode)
+	m.awaitingPRs = false
+
+	approved := prRevDecApproved
+	prs := make([]pr, 3)
+	for i, repo := range []string{"prs", "omm", "prs"} {
+		prs[i] = prWithChecksState(i+1, before, statusStateSuccess)
+		prs[i].Repository.Owner.Login = "dhth"
+		prs[i].Repository.Name = repo
+		prs[i].URL = fmt.Sprintf("https://github.com/dhth/%s/pull/%d", repo, i+1)
+	}
+	prs[0].IsDraft = true
+	prs[2].ReviewDecision = &approved
+	m.setPRs(prs)
+
+	return m
+}
+
+func TestGroupingPRs(t *testing.T) {
+	testCases := []struct {
+		grouping prGrouping
+		expected []string
+	}{
+		{noGrouping, []string{"github.com/dhth/prs:1", "github.com/dhth/omm:2", "github.com/dhth/prs:3"}},
+		{groupByRepo, []string{"group:dhth/prs", "github.com/dhth/prs:1", "github.com/dhth/prs:3", "group:dhth/omm", "github.com/dhth/omm:2"}},
+		{groupByReviewDecision, []string{"group:approved", "github.com/dhth/prs:3", "group:no review decision", "github.com/dhth/prs:1", "github.com/dhth/omm:2"}},
+		{groupByDraft, []string{"group:ready for review", "github.com/dhth/omm:2", "github.com/dhth/prs:3", "group:draft", "github.com/dhth/prs:1"}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.grouping.label(), func(t *testing.T) {
+			m := getGroupingTestModel(t)
+			m.prGrouping = tt.grouping
+			m.setPRListItems()
+
+			assert.Equal(t, tt.expected, getPRListItemKeys(m.prsList.Items()))
+		})
+	}
+}
+
+func TestCollapsingPRGroups(t *testing.T) {
+	m := getGroupingTestModel(t)
+	m.cyclePRGrouping()
+	require.Equal(t, groupByRepo, m.prGrouping)
+	assert.Contains(t, m.prsList.Title, "(grouped by repo)")
+
+	// PRs aren't toggled
+	m.prsList.Select(1)
+	assert.False(t, m.togglePRGroup())
+
+	m.prsList.ResetSelected()
+	require.True(t, m.togglePRGroup())
+	assert.Equal(t, []string{"group:dhth/prs", "group:dhth/omm", "github.com/dhth/omm:2"}, getPRListItemKeys(m.prsList.Items()))
+
+	header, ok := m.prsList.SelectedItem().(prGroupHeader)
+	require.True(t, ok)
+	assert.True(t, header.collapsed)
+	assert.Equal(t, 2, header.numPRs)
+
+	// groups stay collapsed across refreshes
+	m.setRefreshedPRs([]pr{*m.prCache[0].pr, *m.prCache[1].pr, *m.prCache[2].pr})
+	assert.Equal(t, []string{"group:dhth/prs", "group:dhth/omm", "github.com/dhth/omm:2"}, getPRListItemKeys(m.prsList.Items()))
+
+	require.True(t, m.togglePRGroup())
+	assert.Len(t, m.prsList.Items(), 5)
+}
+
+func TestMovingPRCursorSkipsGroupHeaders(t *testing.T) {
+	m := getGroupingTestModel(t)
+	m.prGrouping = groupByRepo
+	m.setPRListItems()
+	m.prsList.Select(2)
+
+	m.movePRCursor(false)
+	selected, ok := m.prsList.SelectedItem().(*prResult)
+	require.True(t, ok)
+	assert.Equal(t, 2, selected.pr.Number)
+
+	// there are no PRs past the last one
+	m.movePRCursor(false)
+	assert.Equal(t, 4, m.prsList.Index())
+
+	m.movePRCursor(true)
+	m.movePRCursor(true)
+	assert.Equal(t, 1, m.prsList.Index())
+}
@@ -2,15 +2,17 @@ package ui
 
 import (
 	"charm.land/bubbles/v2/list"
+	"charm.land/bubbles/v2/textarea"
 	"charm.land/lipgloss/v2"
-	ghapi "github.com/cli/go-gh/v2/pkg/api"
 )
 
 const (
-	fetchingPRsTitle = "fetching PRs..."
+	fetchingPRsTitle     = "fetching PRs..."
+	fetchingMorePRsTitle = "fetching more PRs..."
+	cachedPRsTitleSuffix = "(cached, refreshing...)"
 )
 
-func InitialModel(ghClient *ghapi.GraphQLClient, config Config, mode Mode) Model {
+func InitialModel(prSource prDataSource, config Config, mode Mode) Model {
 	prListDel := newPRListItemDel()
 	prTLListDel := newPRTLListItemDel()
 
@@ -19,27 +21,43 @@ func InitialModel(ghClient *ghapi.GraphQLClient, config Config, mode Mode) Model
 
 	prDetailsCurSectionCache := make(map[string]uint)
 
+	// a missing or unreadable state file just means every PR shows as unread
+	seen, _ := loadSeenState(config.StateFile)
+
+	hostSources := map[string]prDataSource{"": prSource}
+	for host, src := range config.HostSources {
+		hostSources[host] = src
+	}
+
 	m := Model{
 		mode:                     mode,
 		config:                   config,
-		ghClient:                 ghClient,
+		prSource:                 prSource,
+		hostSources:              hostSources,
 		prsList:                  list.New(nil, prListDel, 0, 0),
 		prTLList:                 list.New(nil, prTLListDel, 0, 0),
 		prDetailsCache:           prDetailsCache,
 		prTLCache:                prTLCache,
+		prTLPageInfoCache:        make(map[string]tlPageInfo),
+		collapsedPRGroups:        make(map[string]bool),
+		prDiffCache:              make(map[string]prDiffCacheEntry),
+		reviewThreadsCache:       make(map[string][]prReviewThread),
+		seen:                     seen,
+		repoMetadataCache:        make(map[string]repoMetadataOptions),
+		metadataPicker:           list.New(nil, newMetadataPickerDel(), 0, 0),
+		checkRunList:             list.New(nil, newCheckRunListDel(), 0, 0),
+		checkRunCache:            make(map[string]checkRunDetails),
+		checksPolls:              make(map[string]*checksPoll),
 		showHelp:                 true,
 		terminalDetails:          terminalDetails{width: widthBudgetDefault},
 		prDetailsCurSectionCache: prDetailsCurSectionCache,
+		diskCache:                newDiskCache(config.CacheDir),
+		awaitingPRs:              mode == QueryMode,
 	}
 
 	switch m.mode {
 	case RepoMode:
-		repoListItems := make([]list.Item, len(config.Repos))
-		if mode == RepoMode {
-			for i, issue := range config.Repos {
-				repoListItems[i] = issue
-			}
-		}
+		repoListItems := getRepoListItems(config.Repos)
 		repoListDel := newRepoListItemDel()
 		m.repoList = list.New(repoListItems, repoListDel, 0, 0)
 		m.repoList.Title = "Repos"
@@ -52,6 +70,7 @@ func InitialModel(ghClient *ghapi.GraphQLClient, config Config, mode Mode) Model
 			Bold(true)
 		m.repoList.KeyMap.PrevPage.SetKeys("left", "h", "pgup")
 		m.repoList.KeyMap.NextPage.SetKeys("right", "l", "pgdown")
+		m.skipRepoGroupHeader(-1)
 	case QueryMode:
 		m.activePane = prListView
 	}
@@ -60,13 +79,39 @@ func InitialModel(ghClient *ghapi.GraphQLClient, config Config, mode Mode) Model
 	m.prsList.SetStatusBarItemName("PR", "PRs")
 	m.prsList.DisableQuitKeybindings()
 	m.prsList.SetShowHelp(false)
-	m.prsList.SetFilteringEnabled(false)
+	m.prsList.Filter = filterPRs
 	m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(fetchingColor)).
 		Foreground(lipgloss.Color(defaultBackgroundColor)).
 		Bold(true)
 	m.prsList.KeyMap.PrevPage.SetKeys("left", "h", "pgup")
 	m.prsList.KeyMap.NextPage.SetKeys("right", "l", "pgdown")
 
+	if m.mode == QueryMode {
+		m.tabs = getQueryTabs(config)
+		for i := range m.tabs {
+			m.tabs[i].prsList = m.prsList
+		}
+		m.tabs[0].fetched = true
+	}
+
+	m.composeTA = textarea.New()
+	m.composeTA.ShowLineNumbers = false
+
+	m.metadataPicker.SetStatusBarItemName("option", "options")
+	m.metadataPicker.DisableQuitKeybindings()
+	m.metadataPicker.SetShowHelp(false)
+	m.metadataPicker.Styles.Title = m.metadataPicker.Styles.Title.Background(lipgloss.Color(metadataPickerColor)).
+		Foreground(lipgloss.Color(defaultBackgroundColor)).
+		Bold(true)
+
+	m.checkRunList.SetStatusBarItemName("check run", "check runs")
+	m.checkRunList.DisableQuitKeybindings()
+	m.checkRunList.SetShowHelp(false)
+	m.checkRunList.SetFilteringEnabled(false)
+	m.checkRunList.Styles.Title = m.checkRunList.Styles.Title.Background(lipgloss.Color(checkRunListColor)).
+		Foreground(lipgloss.Color(defaultBackgroundColor)).
+		Bold(true)
+
 	m.prTLList.Title = "fetching timeline..."
 	m.prTLList.SetStatusBarItemName("item", "items")
 	m.prTLList.DisableQuitKeybindings()
@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+)
+
+type OutputFormat uint
+
+const (
+	TableOutput OutputFormat = iota
+	JSONOutput
+	NDJSONOutput
+)
+
+const (
+	listTitleMaxLen = 60
+)
+
+var errUnsupportedOutputFormat = errors.New("unsupported output format")
+
+type prListEntry struct {
+	Repository     string    `json:"repository"`
+	Number         int       `json:"number"`
+	Title          string    `json:"title"`
+	Author         string    `json:"author"`
+	State          string    `json:"state"`
+	IsDraft        bool      `json:"is_draft"`
+	ReviewDecision *string   `json:"review_decision"`
+	Additions      int       `json:"additions"`
+	Deletions      int       `json:"deletions"`
+	Reviews        int       `json:"reviews"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
+	URL            string    `json:"url"`
+}
+
+func newPRListEntry(pr pr) prListEntry {
+	return prListEntry{
+		Repository:     fmt.Sprintf("%s/%s", pr.Repository.Owner.Login, pr.Repository.Name),
+		Number:         pr.Number,
+		Title:          pr.PRTitle,
+		Author:         pr.Author.Login,
+		State:          pr.State,
+		IsDraft:        pr.IsDraft,
+		ReviewDecision: pr.ReviewDecision,
+		Additions:      pr.Additions,
+		Deletions:      pr.Deletions,
+		Reviews:        pr.Reviews.TotalCount,
+		CreatedAt:      pr.CreatedAt,
+		UpdatedAt:      pr.UpdatedAt,
+		URL:            pr.URL,
+	}
+}
+
+// ListPRs fetches PRs the same way the TUI does, and writes them to w in the
+// requested format.
+func ListPRs(prSource prDataSource, config Config, mode Mode, format OutputFormat, w io.Writer) error {
+	var prs []pr
+
+	switch mode {
+	case QueryMode:
+		results, _, err := searchAllPRs(prSource, *config.Query, config.PRCount)
+		if err != nil {
+			return err
+		}
+		prs = results
+	case RepoMode:
+		for _, repo := range config.Repos {
+			repoSource := config.sourceForHost(prSource, repo.Host)
+			results, _, err := searchAllPRs(repoSource, getRepoPRsQuery(repo.Owner, repo.Name), config.PRCount)
+			if err != nil {
+				return err
+			}
+			prs = append(prs, results...)
+		}
+	}
+
+	entries := make([]prListEntry, len(prs))
+	for i, pr := range prs {
+		entries[i] = newPRListEntry(pr)
+	}
+
+	switch format {
+	case TableOutput:
+		return writePRTable(entries, w)
+	case JSONOutput:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(entries)
+	case NDJSONOutput:
+		enc := json.NewEncoder(w)
+		for _, e := range entries {
+			err := enc.Encode(e)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return errUnsupportedOutputFormat
+	}
+}
+
+// searchAllPRs pages through search results until prCount PRs have been
+// fetched, or there are no more results. The page info of the last page is
+// returned as well, to carry on from.
+func searchAllPRs(prSource prDataSource, queryStr string, prCount int) ([]pr, pageInfo, error) {
+	var prs []pr
+	var lastPageInfo pageInfo
+	var after *string
+
+	for len(prs) < prCount {
+		pageSize := min(prCount-len(prs), searchPageSizeMax)
+		results, pageInfo, err := prSource.SearchPRs(queryStr, pageSize, after)
+		if err != nil {
+			return nil, pageInfo, err
+		}
+
+		prs = append(prs, results...)
+		lastPageInfo = pageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+		after = pageInfo.EndCursor
+	}
+
+	return prs, lastPageInfo, nil
+}
+
+func writePRTable(entries []prListEntry, w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+
+	fmt.Fprint(tw, "REPOSITORY\tNUMBER\tTITLE\tAUTHOR\tSTATE\tREVIEW\tUPDATED\n")
+	for _, e := range entries {
+		state := e.State
+		if e.IsDraft {
+			state = "DRAFT"
+		}
+
+		reviewDecision := "-"
+		if e.ReviewDecision != nil {
+			reviewDecision = *e.ReviewDecision
+		}
+
+		fmt.Fprintf(tw, "%s\t#%d\t%s\t%s\t%s\t%s\t%s\n",
+			e.Repository,
+			e.Number,
+			Trim(e.Title, listTitleMaxLen),
+			e.Author,
+			state,
+			reviewDecision,
+			e.UpdatedAt.Local().Format(timeFormat),
+		)
+	}
+
+	return tw.Flush()
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListPRsNDJSON(t *testing.T) {
+	src := &fakePRSource{prs: []pr{{Number: 1, PRTitle: "one"}, {Number: 2, PRTitle: "two"}}}
+	query := "type:pr author:@me"
+	config := Config{PRCount: 10, Query: &query}
+
+	var buf bytes.Buffer
+	err := ListPRs(src, config, QueryMode, NDJSONOutput, &buf)
+	require.NoError(t, err)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 2)
+
+	var entry prListEntry
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, 2, entry.Number)
+	assert.Equal(t, "two", entry.Title)
+}
+
+func TestListPRsRepoModeQueriesEachRepo(t *testing.T) {
+	src := &fakePRSource{prs: []pr{{Number: 1}}}
+	config := Config{PRCount: 10, Repos: []Repo{{Owner: "dhth", Name: "prs"}, {Owner: "dhth", Name: "omm"}}}
+
+	var buf bytes.Buffer
+	err := ListPRs(src, config, RepoMode, JSONOutput, &buf)
+	require.NoError(t, err)
+
+	var entries []prListEntry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "type:pr repo:dhth/omm sort:updated-desc", src.lastQuery)
+}
+
+func TestListPRsPagesBeyondSearchPageSize(t *testing.T) {
+	prs := make([]pr, 250)
+	for i := range prs {
+		prs[i] = pr{Number: i + 1}
+	}
+	src := &fakePRSource{prs: prs}
+	query := "type:pr"
+	config := Config{PRCount: 220, Query: &query}
+
+	var buf bytes.Buffer
+	err := ListPRs(src, config, QueryMode, JSONOutput, &buf)
+	require.NoError(t, err)
+
+	var entries []prListEntry
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &entries))
+	assert.Len(t, entries, 220)
+	assert.Equal(t, 220, entries[219].Number)
+	assert.Equal(t, 3, src.numSearches)
+}
+
+func TestGetRepoListItemsGroupsByOwner(t *testing.T) {
+	repos := []Repo{
+		{Owner: "dhth", Name: "prs"},
+		{Owner: "neovim", Name: "neovim"},
+		{Owner: "dhth", Name: "omm"},
+	}
+
+	items := getRepoListItems(repos)
+
+	require.Len(t, items, 5)
+	assert.Equal(t, repoGroupHeader{owner: "dhth", numRepos: 2}, items[0])
+	assert.Equal(t, repos[0], items[1])
+	assert.Equal(t, repos[2], items[2])
+	assert.Equal(t, repoGroupHeader{owner: "neovim", numRepos: 1}, items[3])
+	assert.Equal(t, repos[1], items[4])
+}
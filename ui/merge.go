@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// getMergeReadiness returns the reasons a PR can't be merged at all, and the
+// ones it's still waiting on, which auto-merge can take care of.
+func getMergeReadiness(pr *pr, details *prDetails) ([]string, []string) {
+	var blockers, waitingOn []string
+
+	if pr.State != prStateOpen {
+		blockers = append(blockers, fmt.Sprintf("it's %s", strings.ToLower(pr.State)))
+	}
+	if pr.IsDraft {
+		blockers = append(blockers, "it's a draft")
+	}
+
+	switch pr.Mergeable {
+	case mergeableConflicting:
+		blockers = append(blockers, "it has conflicts")
+	case mergeableUnknown:
+		waitingOn = append(waitingOn, "mergeability check")
+	}
+
+	if pr.ReviewDecision != nil {
+		switch *pr.ReviewDecision {
+		case prRevDecChangesReq:
+			blockers = append(blockers, "changes were requested")
+		case prRevDecRevReq:
+			waitingOn = append(waitingOn, "approval")
+		}
+	}
+
+	if details != nil && len(details.LastCommit.Nodes) > 0 && details.LastCommit.Nodes[0].Commit.StatusCheckRollup != nil {
+		switch details.LastCommit.Nodes[0].Commit.StatusCheckRollup.State {
+		case statusStateFailure, statusStateError:
+			blockers = append(blockers, "checks are failing")
+		case checksStatePending, checksStateExpected:
+			waitingOn = append(waitingOn, "checks")
+		}
+	}
+
+	return blockers, waitingOn
+}
+
+// startMerge prompts for a merge method for the selected PR, and then for a
+// confirmation to merge it, or to enable auto-merge if it's still waiting on
+// checks or approvals.
+func (m *Model) startMerge() {
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return
+	}
+
+	if prRes.pr.ID == "" {
+		m.message = "PR's ID is not available; reload the PR list with ctrl+r"
+		return
+	}
+
+	details, ok := m.prDetailsCache[prRes.identifier]
+	if !ok {
+		m.message = "PR details were not retrieved yet"
+		return
+	}
+
+	blockers, waitingOn := getMergeReadiness(prRes.pr, &details)
+	if len(blockers) > 0 {
+		m.message = fmt.Sprintf("Can't merge #%d: %s", prRes.pr.Number, strings.Join(blockers, ", "))
+		return
+	}
+
+	prSource := m.prSourceForHost(getPRHost(prRes.pr))
+	m.askForChoice(fmt.Sprintf("Merge #%d with", prRes.pr.Number), "m: merge, s: squash, r: rebase",
+		func(m *Model, key string) tea.Cmd {
+			var method PullRequestMergeMethod
+			switch key {
+			case "m":
+				method = mergeMethodMerge
+			case "s":
+				method = mergeMethodSquash
+			case "r":
+				method = mergeMethodRebase
+			default:
+				m.message = "cancelled"
+				return nil
+			}
+
+			if len(waitingOn) > 0 {
+				m.askForConfirmation(
+					fmt.Sprintf("#%d is waiting on %s; enable auto-merge (%s)?", prRes.pr.Number, strings.Join(waitingOn, ", "), method.label()),
+					"enabling auto-merge...",
+					mergePRCmd(prSource, prRes, method, true),
+				)
+				return nil
+			}
+
+			m.askForConfirmation(
+				fmt.Sprintf("Merge #%d (%s)?", prRes.pr.Number, method.label()),
+				"merging...",
+				mergePRCmd(prSource, prRes, method, false),
+			)
+			return nil
+		})
+}
+
+func mergePRCmd(prSource prDataSource, prRes *prResult, method PullRequestMergeMethod, auto bool) tea.Cmd {
+	prID := prRes.pr.ID
+	msg := prMergedMsg{
+		identifier: prRes.identifier,
+		prNumber:   prRes.pr.Number,
+		method:     method,
+		auto:       auto,
+	}
+
+	return func() tea.Msg {
+		merger, ok := prSource.(prMerger)
+		if !ok {
+			msg.err = errActionNotSupported
+			return msg
+		}
+
+		if auto {
+			msg.err = merger.EnablePRAutoMerge(prID, method)
+		} else {
+			msg.err = merger.MergePR(prID, method)
+		}
+		return msg
+	}
+}
+
+// markPRMerged updates the state of a merged PR in the PR list.
+func (m *Model) markPRMerged(identifier string) {
+	for _, prRes := range m.prCache {
+		if prRes.identifier != identifier {
+			continue
+		}
+
+		prRes.pr.State = prStateMerged
+		prRes.title = getPRTitle(prRes.pr, prRes.unread)
+		prRes.description = getPRDesc(prRes.pr, m.mode, m.terminalDetails)
+		return
+	}
+}
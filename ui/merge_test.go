@@ -0,0 +1,113 @@
+package ui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMergingSource struct {
+	fakePRSource
+	merged      []PullRequestMergeMethod
+	autoMerged  []PullRequestMergeMethod
+	mergeErrors error
+}
+
+func (s *fakeMergingSource) MergePR(_ string, method PullRequestMergeMethod) error {
+	s.merged = append(s.merged, method)
+	return s.mergeErrors
+}
+
+func (s *fakeMergingSource) EnablePRAutoMerge(_ string, method PullRequestMergeMethod) error {
+	s.autoMerged = append(s.autoMerged, method)
+	return s.mergeErrors
+}
+
+func detailsWithChecksState(state string) prDetails {
+	var details prDetails
+	details.LastCommit.Nodes = make([]prLastCommitNode, 1)
+	details.LastCommit.Nodes[0].Commit.StatusCheckRollup = &prStatusCheckRollup{State: state}
+
+	return details
+}
+
+func TestGetMergeReadiness(t *testing.T) {
+	changesRequested := prRevDecChangesReq
+	reviewRequired := prRevDecRevReq
+
+	testCases := []struct {
+		name          string
+		pr            pr
+		checksState   string
+		wantBlockers  []string
+		wantWaitingOn []string
+	}{
+		{
+			name:        "ready",
+			pr:          pr{State: prStateOpen, Mergeable: "MERGEABLE"},
+			checksState: statusStateSuccess,
+		},
+		{
+			name:         "conflicts and changes requested",
+			pr:           pr{State: prStateOpen, Mergeable: mergeableConflicting, ReviewDecision: &changesRequested},
+			checksState:  statusStateSuccess,
+			wantBlockers: []string{"it has conflicts", "changes were requested"},
+		},
+		{
+			name:          "waiting on approval and checks",
+			pr:            pr{State: prStateOpen, Mergeable: "MERGEABLE", ReviewDecision: &reviewRequired},
+			checksState:   checksStatePending,
+			wantWaitingOn: []string{"approval", "checks"},
+		},
+		{
+			name:         "failing checks on a draft",
+			pr:           pr{State: prStateOpen, Mergeable: "MERGEABLE", IsDraft: true},
+			checksState:  statusStateFailure,
+			wantBlockers: []string{"it's a draft", "checks are failing"},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			details := detailsWithChecksState(tt.checksState)
+			blockers, waitingOn := getMergeReadiness(&tt.pr, &details)
+			assert.Equal(t, tt.wantBlockers, blockers)
+			assert.Equal(t, tt.wantWaitingOn, waitingOn)
+		})
+	}
+}
+
+func TestMergeUsesAutoMergeWhenWaitingOnChecks(t *testing.T) {
+	src := &fakeMergingSource{}
+	m := newTestModel(t, src, pr{ID: "PR_1", Number: 1, State: prStateOpen, Mergeable: "MERGEABLE"})
+	m.prDetailsCache[m.prCache[0].identifier] = detailsWithChecksState(checksStatePending)
+
+	m.startMerge()
+	require.NotNil(t, m.confirmation)
+
+	m.handleConfirmation(tea.KeyPressMsg{Code: 's', Text: "s"})
+	require.NotNil(t, m.confirmation)
+	assert.Contains(t, m.confirmation.prompt, "enable auto-merge (squash)")
+
+	cmd := m.handleConfirmation(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(prMergedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	assert.True(t, msg.auto)
+	assert.Equal(t, []PullRequestMergeMethod{mergeMethodSquash}, src.autoMerged)
+	assert.Empty(t, src.merged)
+}
+
+func TestMergeIsRefusedWithBlockers(t *testing.T) {
+	m := newTestModel(t, &fakeMergingSource{}, pr{ID: "PR_1", Number: 1, State: prStateOpen, Mergeable: mergeableConflicting})
+	m.prDetailsCache[m.prCache[0].identifier] = detailsWithChecksState(statusStateSuccess)
+
+	m.startMerge()
+
+	assert.Nil(t, m.confirmation)
+	assert.Equal(t, "Can't merge #1: it has conflicts", m.message)
+}
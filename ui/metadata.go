@@ -0,0 +1,424 @@
+package ui
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+	ghapi "github.com/cli/go-gh/v2/pkg/api"
+)
+
+const (
+	metadataEditOptions         = "l/a/r/m"
+	reviewRequestsClientTimeout = 10 * time.Second
+)
+
+var (
+	errCouldntRemoveReviewRequest = errors.New("couldn't remove review request")
+	// Github's GraphQL API can only add review requests
+	errReviewRequestsCantBeRemoved = errors.New("review requests can't be removed via GraphQL")
+)
+
+// metadataOptionItem is a label, user or milestone that can be toggled in the
+// metadata picker.
+type metadataOptionItem struct {
+	option   metadataOption
+	selected bool
+}
+
+func (i metadataOptionItem) Title() string {
+	if i.selected {
+		return "[x] " + i.option.name
+	}
+	return "[ ] " + i.option.name
+}
+
+func (i metadataOptionItem) Description() string {
+	return ""
+}
+
+func (i metadataOptionItem) FilterValue() string {
+	return i.option.name
+}
+
+func newMetadataPickerDel() list.DefaultDelegate {
+	d := list.NewDefaultDelegate()
+	d.ShowDescription = false
+	d.SetSpacing(0)
+
+	d.Styles.SelectedTitle = d.Styles.
+		SelectedTitle.
+		Foreground(lipgloss.Color(metadataPickerColor)).
+		BorderLeftForeground(lipgloss.Color(metadataPickerColor))
+
+	return d
+}
+
+// startMetadataEdit asks which of the selected PR's labels, assignees,
+// reviewers or milestone are to be edited.
+func (m *Model) startMetadataEdit() {
+	m.askForChoice("Edit labels, assignees, reviewers or milestone?", metadataEditOptions, func(m *Model, key string) tea.Cmd {
+		var kind prMetadataKind
+		switch key {
+		case "l":
+			kind = labelsMetadata
+		case "a":
+			kind = assigneesMetadata
+		case "r":
+			kind = reviewersMetadata
+		case "m":
+			kind = milestoneMetadata
+		default:
+			m.message = "cancelled"
+			return nil
+		}
+
+		return m.openMetadataPicker(kind)
+	})
+}
+
+func (m *Model) openMetadataPicker(kind prMetadataKind) tea.Cmd {
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	host := getPRHost(prRes.pr)
+	repoOwner := prRes.pr.Repository.Owner.Login
+	repoName := prRes.pr.Repository.Name
+	options, ok := m.repoMetadataCache[fmt.Sprintf("%s/%s/%s", host, repoOwner, repoName)]
+	if ok {
+		m.showMetadataPicker(prRes, kind, options)
+		return nil
+	}
+
+	m.message = fmt.Sprintf("fetching %s...", kind.label())
+	return fetchRepoMetadataOptions(m.prSourceForHost(host), prRes.identifier, host, repoOwner, repoName, kind)
+}
+
+func fetchRepoMetadataOptions(prSource prDataSource, identifier, host, repoOwner, repoName string, kind prMetadataKind) tea.Cmd {
+	return func() tea.Msg {
+		editor, ok := prSource.(prMetadataEditor)
+		if !ok {
+			return repoMetadataOptionsFetchedMsg{identifier: identifier, kind: kind, err: errActionNotSupported}
+		}
+
+		options, err := editor.GetRepoMetadataOptions(repoOwner, repoName)
+		return repoMetadataOptionsFetchedMsg{identifier, host, repoOwner, repoName, kind, options, err}
+	}
+}
+
+func (m *Model) showMetadataPicker(prRes *prResult, kind prMetadataKind, options repoMetadataOptions) {
+	details, ok := m.prDetailsCache[prRes.identifier]
+	if !ok {
+		m.message = "PR details were not retrieved"
+		return
+	}
+
+	m.metadataPickerKind = kind
+	m.metadataPicker.ResetFilter()
+	m.metadataPicker.SetItems(getMetadataPickerItems(kind, options, details))
+	m.metadataPicker.ResetSelected()
+	m.metadataPicker.Title = fmt.Sprintf("Edit %s (#%d)", kind.label(), prRes.pr.Number)
+	m.activePane = metadataPickerView
+}
+
+func getMetadataPickerItems(kind prMetadataKind, options repoMetadataOptions, details prDetails) []list.Item {
+	var opts []metadataOption
+	switch kind {
+	case labelsMetadata:
+		for _, l := range options.Labels.Nodes {
+			opts = append(opts, metadataOption{l.ID, l.Name})
+		}
+	case assigneesMetadata, reviewersMetadata:
+		for _, u := range options.AssignableUsers.Nodes {
+			// Github doesn't let authors review their own PRs
+			if kind == reviewersMetadata && u.Login == details.Author.Login {
+				continue
+			}
+			opts = append(opts, metadataOption{u.ID, u.Login})
+		}
+	case milestoneMetadata:
+		for _, ms := range options.Milestones.Nodes {
+			opts = append(opts, metadataOption{ms.ID, ms.Title})
+		}
+	}
+
+	items := make([]list.Item, len(opts))
+	for i, o := range opts {
+		items[i] = metadataOptionItem{o, isMetadataSelected(kind, details, o.id)}
+	}
+	return items
+}
+
+func isMetadataSelected(kind prMetadataKind, details prDetails, id string) bool {
+	switch kind {
+	case labelsMetadata:
+		for _, l := range details.Labels.Nodes {
+			if l.ID == id {
+				return true
+			}
+		}
+	case assigneesMetadata:
+		for _, a := range details.Assignees.Nodes {
+			if a.ID == id {
+				return true
+			}
+		}
+	case reviewersMetadata:
+		return slices.Contains(getRequestedReviewerIDs(details), id)
+	case milestoneMetadata:
+		return details.Milestone != nil && details.Milestone.ID == id
+	}
+	return false
+}
+
+// getRequestedReviewerIDs returns the IDs of the users reviews have been
+// requested from; only users can be picked as reviewers.
+func getRequestedReviewerIDs(details prDetails) []string {
+	if details.ReviewRequests == nil {
+		return nil
+	}
+
+	var ids []string
+	for _, r := range details.ReviewRequests.Nodes {
+		if r.RequestedReviewer != nil && r.RequestedReviewer.Type == requestedReviewerUser {
+			ids = append(ids, r.RequestedReviewer.User.ID)
+		}
+	}
+	return ids
+}
+
+// applyMetadataEdit returns details with edit applied. details is left
+// untouched, as it may still be referenced from the cache.
+func applyMetadataEdit(details prDetails, edit prMetadataEdit) prDetails {
+	switch edit.kind {
+	case labelsMetadata:
+		var labels []prLabel
+		for _, l := range details.Labels.Nodes {
+			if l.ID != edit.option.id {
+				labels = append(labels, l)
+			}
+		}
+		if edit.add {
+			labels = append(labels, prLabel{edit.option.id, edit.option.name})
+		}
+		details.Labels.Nodes = labels
+
+	case assigneesMetadata:
+		var assignees []prUser
+		for _, a := range details.Assignees.Nodes {
+			if a.ID != edit.option.id {
+				assignees = append(assignees, a)
+			}
+		}
+		if edit.add {
+			assignees = append(assignees, prUser{edit.option.id, edit.option.name})
+		}
+		details.Assignees.Nodes = assignees
+
+	case reviewersMetadata:
+		var requests []prReviewRequest
+		if details.ReviewRequests != nil {
+			for _, r := range details.ReviewRequests.Nodes {
+				if r.RequestedReviewer != nil && r.RequestedReviewer.Type == requestedReviewerUser && r.RequestedReviewer.User.ID == edit.option.id {
+					continue
+				}
+				requests = append(requests, r)
+			}
+		}
+		if edit.add {
+			requests = append(requests, prReviewRequest{&prRequestedReviewer{
+				Type: requestedReviewerUser,
+				User: prUser{edit.option.id, edit.option.name},
+			}})
+		}
+		details.ReviewRequests = &struct {
+			Nodes []prReviewRequest
+		}{requests}
+
+	case milestoneMetadata:
+		details.Milestone = nil
+		if edit.add {
+			details.Milestone = &prMilestone{edit.option.id, edit.option.name}
+		}
+	}
+
+	return details
+}
+
+// getMetadataEditRevert returns the edit that brings details back, once edit
+// has been applied to it.
+func getMetadataEditRevert(details prDetails, edit prMetadataEdit) prMetadataEdit {
+	revert := edit
+	revert.add = !edit.add
+	if edit.kind == milestoneMetadata && edit.add && details.Milestone != nil {
+		revert = prMetadataEdit{
+			kind:   milestoneMetadata,
+			option: metadataOption{details.Milestone.ID, details.Milestone.Title},
+			add:    true,
+		}
+	}
+	return revert
+}
+
+func (m Model) updateMetadataPickerView(msg tea.KeyPressMsg) (Model, tea.Cmd) {
+	var cmd tea.Cmd
+	if m.metadataPicker.FilterState() == list.Filtering {
+		m.metadataPicker, cmd = m.metadataPicker.Update(msg)
+		return m, cmd
+	}
+
+	switch msg.String() {
+	case "esc":
+		if m.metadataPicker.FilterState() == list.FilterApplied {
+			m.metadataPicker.ResetFilter()
+			return m, nil
+		}
+		m.activePane = prDetailsView
+		return m, nil
+	case "q", "ctrl+c":
+		m.activePane = prDetailsView
+		return m, nil
+	case "Q":
+		return m, tea.Quit
+	case "enter", "space":
+		return m, m.toggleMetadataOption()
+	}
+
+	m.metadataPicker, cmd = m.metadataPicker.Update(msg)
+	return m, cmd
+}
+
+// toggleMetadataOption adds (or removes) the option under the cursor. The
+// cached PR details are updated right away, and reverted if Github rejects
+// the change.
+func (m *Model) toggleMetadataOption() tea.Cmd {
+	item, ok := m.metadataPicker.SelectedItem().(metadataOptionItem)
+	if !ok {
+		return nil
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	details, ok := m.prDetailsCache[prRes.identifier]
+	if !ok {
+		return nil
+	}
+
+	edit := prMetadataEdit{
+		kind:   m.metadataPickerKind,
+		option: item.option,
+		add:    !item.selected,
+	}
+	revert := getMetadataEditRevert(details, edit)
+
+	updated := applyMetadataEdit(details, edit)
+	m.prDetailsCache[prRes.identifier] = updated
+	cmd := m.refreshMetadataViews(prRes.identifier)
+
+	return tea.Batch(cmd, editPRMetadataCmd(m.prSourceForHost(getPRHost(prRes.pr)), prRes, edit, revert))
+}
+
+func editPRMetadataCmd(prSource prDataSource, prRes *prResult, edit, revert prMetadataEdit) tea.Cmd {
+	msg := prMetadataEditedMsg{
+		identifier: prRes.identifier,
+		edit:       edit,
+		revert:     revert,
+	}
+
+	return func() tea.Msg {
+		editor, ok := prSource.(prMetadataEditor)
+		if !ok {
+			msg.err = errActionNotSupported
+			return msg
+		}
+
+		if edit.kind == reviewersMetadata && !edit.add {
+			msg.err = editor.RemoveReviewRequest(prRes.pr.Repository.Owner.Login, prRes.pr.Repository.Name, prRes.pr.Number, edit.option.name)
+			return msg
+		}
+
+		msg.err = editor.EditPRMetadata(prRes.pr.ID, msg.edit)
+		return msg
+	}
+}
+
+// newReviewRequestsClient returns a REST client for taking back review
+// requests on host; an empty host uses the default host.
+func newReviewRequestsClient(host string) (*ghapi.RESTClient, error) {
+	return ghapi.NewRESTClient(ghapi.ClientOptions{
+		Host:    host,
+		Timeout: reviewRequestsClientTimeout,
+	})
+}
+
+// deleteReviewRequest takes back the review requested from the user with the
+// given login, via the REST API; unlike with GraphQL, that leaves the other
+// review requests (including the ones from bots and teams) alone.
+func deleteReviewRequest(client restRequester, repoOwner, repoName string, prNumber int, login string) error {
+	body, err := json.Marshal(map[string][]string{"reviewers": {login}})
+	if err != nil {
+		return err
+	}
+
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", url.PathEscape(repoOwner), url.PathEscape(repoName), prNumber)
+	resp, err := client.Request(http.MethodDelete, path, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("%w: %s", errCouldntRemoveReviewRequest, err.Error())
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+// refreshMetadataViews re-renders the details view, and the picker's
+// selections, if they're showing the PR with identifier.
+func (m *Model) refreshMetadataViews(identifier string) tea.Cmd {
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok || prRes.identifier != identifier {
+		return nil
+	}
+
+	details, ok := m.prDetailsCache[identifier]
+	if !ok {
+		return nil
+	}
+
+	yOffset := m.prDetailsVP.YOffset()
+	m.setPRDetailsContent(details, PRDetailsSectionList[m.prDetailsCurrentSection])
+	m.prDetailsVP.SetYOffset(yOffset)
+
+	if m.activePane != metadataPickerView {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for i, listItem := range m.metadataPicker.Items() {
+		item, ok := listItem.(metadataOptionItem)
+		if !ok {
+			continue
+		}
+
+		selected := isMetadataSelected(m.metadataPickerKind, details, item.option.id)
+		if selected == item.selected {
+			continue
+		}
+
+		item.selected = selected
+		cmds = append(cmds, m.metadataPicker.SetItem(i, item))
+	}
+
+	return tea.Batch(cmds...)
+}
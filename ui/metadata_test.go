@@ -0,0 +1,162 @@
+package ui
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeMetadataEditingSource struct {
+	fakePRSource
+	edits                 []prMetadataEdit
+	editErr               error
+	removedReviewRequests []string
+}
+
+// GetRepoMetadataOptions returns the labels bug and docs for every repo.
+func (s *fakeMetadataEditingSource) GetRepoMetadataOptions(_, _ string) (repoMetadataOptions, error) {
+	var options repoMetadataOptions
+	options.Labels.Nodes = []prLabel{{"L_1", "bug"}, {"L_2", "docs"}}
+	return options, nil
+}
+
+func (s *fakeMetadataEditingSource) EditPRMetadata(_ string, edit prMetadataEdit) error {
+	s.edits = append(s.edits, edit)
+	return s.editErr
+}
+
+func (s *fakeMetadataEditingSource) RemoveReviewRequest(_, _ string, _ int, login string) error {
+	s.removedReviewRequests = append(s.removedReviewRequests, login)
+	return nil
+}
+
+// openLabelsPicker opens the labels picker from the details of the first PR in
+// m, which is labelled bug.
+func openLabelsPicker(t *testing.T, m *Model) {
+	t.Helper()
+
+	var details prDetails
+	details.Labels.Nodes = []prLabel{{"L_1", "bug"}}
+	m.prDetailsCache[m.prCache[0].identifier] = details
+	m.activePane = prDetailsView
+
+	m.startMetadataEdit()
+	cmd := m.handleConfirmation(tea.KeyPressMsg{Code: 'l', Text: "l"})
+	require.NotNil(t, cmd)
+
+	updated, _ := m.Update(cmd())
+	*m = updated.(Model)
+	require.Equal(t, metadataPickerView, m.activePane)
+}
+
+func TestMetadataPickerMarksCurrentLabels(t *testing.T) {
+	m := newTestModel(t, &fakeMetadataEditingSource{}, pr{ID: "PR_1", Number: 1})
+	openLabelsPicker(t, &m)
+
+	items := m.metadataPicker.Items()
+	require.Len(t, items, 2)
+	assert.True(t, items[0].(metadataOptionItem).selected)
+	assert.False(t, items[1].(metadataOptionItem).selected)
+}
+
+func TestToggleMetadataOptionUpdatesDetailsOptimistically(t *testing.T) {
+	src := &fakeMetadataEditingSource{}
+	m := newTestModel(t, src, pr{ID: "PR_1", Number: 1})
+	openLabelsPicker(t, &m)
+	identifier := m.prCache[0].identifier
+
+	m.metadataPicker.Select(1)
+	m, cmd := m.updateMetadataPickerView(tea.KeyPressMsg{Code: tea.KeyEnter})
+	require.NotNil(t, cmd)
+
+	assert.Equal(t, []prLabel{{"L_1", "bug"}, {"L_2", "docs"}}, m.prDetailsCache[identifier].Labels.Nodes)
+	assert.True(t, m.metadataPicker.Items()[1].(metadataOptionItem).selected)
+
+	editedMsg, ok := cmd().(prMetadataEditedMsg)
+	require.True(t, ok)
+	require.NoError(t, editedMsg.err)
+	require.Len(t, src.edits, 1)
+	assert.True(t, src.edits[0].add)
+	assert.Equal(t, "L_2", src.edits[0].option.id)
+}
+
+func TestFailedMetadataEditIsReverted(t *testing.T) {
+	m := newTestModel(t, &fakeMetadataEditingSource{}, pr{ID: "PR_1", Number: 1})
+	openLabelsPicker(t, &m)
+	identifier := m.prCache[0].identifier
+
+	m, _ = m.updateMetadataPickerView(tea.KeyPressMsg{Code: tea.KeyEnter})
+	assert.Empty(t, m.prDetailsCache[identifier].Labels.Nodes)
+
+	updated, _ := m.Update(prMetadataEditedMsg{
+		identifier: identifier,
+		edit:       prMetadataEdit{kind: labelsMetadata, option: metadataOption{"L_1", "bug"}},
+		revert:     prMetadataEdit{kind: labelsMetadata, option: metadataOption{"L_1", "bug"}, add: true},
+		err:        errors.New("forbidden"),
+	})
+	m = updated.(Model)
+
+	assert.Equal(t, []prLabel{{"L_1", "bug"}}, m.prDetailsCache[identifier].Labels.Nodes)
+	assert.True(t, m.metadataPicker.Items()[0].(metadataOptionItem).selected)
+	assert.Equal(t, "Couldn't update labels: forbidden", m.message)
+}
+
+func TestApplyMetadataEditKeepsTeamReviewers(t *testing.T) {
+	var details prDetails
+	details.ReviewRequests = &struct {
+		Nodes []prReviewRequest
+	}{[]prReviewRequest{{&prRequestedReviewer{Type: requestedReviewerTeam}}}}
+	details.ReviewRequests.Nodes[0].RequestedReviewer.Team.ID = "T_1"
+
+	got := applyMetadataEdit(details, prMetadataEdit{kind: reviewersMetadata, option: metadataOption{"U_1", "dhth"}, add: true})
+
+	assert.Equal(t, []string{"U_1"}, getRequestedReviewerIDs(got))
+	require.Len(t, got.ReviewRequests.Nodes, 2)
+	assert.Equal(t, "T_1", got.ReviewRequests.Nodes[0].RequestedReviewer.Team.ID)
+	assert.Len(t, details.ReviewRequests.Nodes, 1)
+}
+
+func TestMetadataEditRevertRestoresPreviousMilestone(t *testing.T) {
+	var details prDetails
+	details.Milestone = &prMilestone{"M_1", "v1"}
+	edit := prMetadataEdit{kind: milestoneMetadata, option: metadataOption{"M_2", "v2"}, add: true}
+
+	revert := getMetadataEditRevert(details, edit)
+	got := applyMetadataEdit(applyMetadataEdit(details, edit), revert)
+
+	assert.Equal(t, details.Milestone, got.Milestone)
+}
+
+func TestRemovingReviewerTakesBackOnlyTheirRequest(t *testing.T) {
+	src := &fakeMetadataEditingSource{}
+	p := &pr{ID: "PR_1", Number: 1}
+	p.Repository.Owner.Login = "dhth"
+	p.Repository.Name = "prs"
+	edit := prMetadataEdit{kind: reviewersMetadata, option: metadataOption{"U_1", "other"}}
+
+	msg, ok := editPRMetadataCmd(src, &prResult{pr: p}, edit, edit)().(prMetadataEditedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+
+	assert.Equal(t, []string{"other"}, src.removedReviewRequests)
+	assert.Empty(t, src.edits)
+}
+
+func TestDeleteReviewRequest(t *testing.T) {
+	client := &fakeRESTClient{}
+
+	err := deleteReviewRequest(client, "dhth", "prs", 1, "other")
+	require.NoError(t, err)
+	assert.Equal(t, http.MethodDelete, client.method)
+	assert.Equal(t, "repos/dhth/prs/pulls/1/requested_reviewers", client.path)
+	assert.JSONEq(t, `{"reviewers": ["other"]}`, client.reqBody)
+
+	client = &fakeRESTClient{err: io.ErrUnexpectedEOF}
+	err = deleteReviewRequest(client, "dhth", "prs", 1, "other")
+	assert.ErrorIs(t, err, errCouldntRemoveReviewRequest)
+}
@@ -4,10 +4,10 @@ import (
 	"time"
 
 	"charm.land/bubbles/v2/list"
+	"charm.land/bubbles/v2/textarea"
 	"charm.land/bubbles/v2/viewport"
 	tea "charm.land/bubbletea/v2"
 	"github.com/charmbracelet/glamour"
-	ghapi "github.com/cli/go-gh/v2/pkg/api"
 )
 
 type Pane uint
@@ -19,6 +19,11 @@ const (
 	reviewPRListView
 	prTLListView
 	prTLItemDetailView
+	prDiffView
+	checkRunListView
+	checkRunDetailView
+	composeView
+	metadataPickerView
 	helpView
 )
 
@@ -32,13 +37,28 @@ const (
 type Model struct {
 	mode                     Mode
 	config                   Config
-	ghClient                 *ghapi.GraphQLClient
+	prSource                 prDataSource
+	hostSources              map[string]prDataSource
+	repoHost                 string
 	repoOwner                string
 	repoName                 string
 	repoList                 list.Model
 	prsList                  list.Model
 	prTLList                 list.Model
 	prCache                  []*prResult
+	prSort                   prSortKey
+	prGrouping               prGrouping
+	collapsedPRGroups        map[string]bool
+	tabs                     []queryTab
+	activeTab                int
+	prsPageInfo              pageInfo
+	fetchingMorePRs          bool
+	prsGeneration            int
+	prefetchQueue            []prRef
+	prefetchInFlight         int
+	diskCache                *diskCache
+	awaitingPRs              bool
+	prsFromCache             bool
 	prTLItemDetailVP         viewport.Model
 	prTLItemDetailVPReady    bool
 	prDetailsTitle           string
@@ -46,7 +66,31 @@ type Model struct {
 	prDetailsVP              viewport.Model
 	prDetailsVPReady         bool
 	prDetailsCache           map[string]prDetails
+	fetchingMorePRDetails    bool
+	prDiffVP                 viewport.Model
+	prDiffVPReady            bool
+	prDiffTitle              string
+	prDiffFiles              []diffFile
+	prDiffCache              map[string]prDiffCacheEntry
+	checkRunList             list.Model
+	checkRunCache            map[string]checkRunDetails
+	checkRunVP               viewport.Model
+	checkRunVPReady          bool
+	checkRunTitle            string
+	checkRunDetailsURL       *string
+	checksPolls              map[string]*checksPoll
+	composeTA                textarea.Model
+	composer                 *composer
+	confirmation             *confirmation
 	prTLCache                map[string][]*prTLItemResult
+	prTLPageInfoCache        map[string]tlPageInfo
+	prTLFilter               tlFilter
+	fetchingEarlierTLItems   bool
+	reviewThreadsCache       map[string][]prReviewThread
+	repoMetadataCache        map[string]repoMetadataOptions
+	seen                     *seenState
+	metadataPicker           list.Model
+	metadataPickerKind       prMetadataKind
 	message                  string
 	helpVP                   viewport.Model
 	helpVPReady              bool
@@ -60,14 +104,20 @@ type Model struct {
 	prDetailsCurrentSection  uint
 	prDetailsCurSectionCache map[string]uint
 	prRevCurCmtNum           uint
+	rateLimit                *rateLimit
 }
 
 func (m Model) Init() tea.Cmd {
 	var cmds []tea.Cmd
 	cmds = append(cmds, hideHelp(time.Minute*1))
 
+	if m.config.RefreshInterval > 0 {
+		cmds = append(cmds, scheduleRefresh(m.config.RefreshInterval))
+	}
+
 	if m.mode == QueryMode {
-		cmds = append(cmds, fetchPRSFromQuery(m.ghClient, *m.config.Query, m.config.PRCount))
+		cmds = append(cmds, loadCachedPRs(m.diskCache, m.prsHost(), m.prsQuery()))
+		cmds = append(cmds, fetchPRSFromQuery(m.prSource, m.prsQuery(), m.config.PRCount))
 	}
 
 	return tea.Batch(cmds...)
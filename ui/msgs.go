@@ -1,35 +1,82 @@
 package ui
 
+import "time"
+
 type hideHelpMsg struct{}
 
 type repoChosenMsg struct {
-	repo string
+	repo Repo
 }
 
 type prsFetchedMsg struct {
-	prs []pr
+	query    string
+	prs      []pr
+	pageInfo pageInfo
+	err      error
+}
+
+type prsRefreshedMsg struct {
+	query    string
+	prs      []pr
+	pageInfo pageInfo
+	err      error
+}
+
+type refreshTickMsg struct{}
+
+type notificationSentMsg struct {
+	err error
+}
+
+type droppedPRFetchedMsg struct {
+	pr      pr
+	details prDetails
+	err     error
+}
+
+type seenStateSavedMsg struct {
 	err error
 }
 
+type morePRsFetchedMsg struct {
+	query      string
+	generation int
+	prs        []pr
+	pageInfo   pageInfo
+	err        error
+}
+
 type prMetadataFetchedMsg struct {
-	repoOwner string
-	repoName  string
-	prNumber  int
-	metadata  prDetails
-	err       error
+	identifier string
+	metadata   prDetails
+	err        error
 }
 
-type reviewPRsFetchedMsg prsFetchedMsg
+type cachedPRsLoadedMsg struct {
+	query string
+	prs   []pr
+}
 
-type authoredPRsFetchedMsg prsFetchedMsg
+type prsPrefetchedMsg struct {
+	prs  []prRef
+	data []prData
+	err  error
+}
 
 type prTLFetchedMsg struct {
-	repoOwner string
-	repoName  string
-	prNumber  int
-	prTLItems []prTLItem
-	setItems  bool
-	err       error
+	identifier string
+	prNumber   int
+	prTLItems  []prTLItem
+	pageInfo   tlPageInfo
+	setItems   bool
+	err        error
+}
+
+type earlierPRTLItemsFetchedMsg struct {
+	identifier string
+	prTLItems  []prTLItem
+	pageInfo   tlPageInfo
+	err        error
 }
 
 type urlOpenedinBrowserMsg struct {
@@ -41,6 +88,104 @@ type prDiffDoneMsg struct {
 	err error
 }
 
+type prDiffFetchedMsg struct {
+	identifier  string
+	updatedAt   time.Time
+	diff        string
+	showInPager bool
+	err         error
+}
+
 type prViewDoneMsg struct {
 	err error
 }
+
+type reviewSubmittedMsg struct {
+	identifier string
+	host       string
+	repoOwner  string
+	repoName   string
+	prNumber   int
+	state      string
+	err        error
+}
+
+type prMergedMsg struct {
+	identifier string
+	prNumber   int
+	method     PullRequestMergeMethod
+	auto       bool
+	err        error
+}
+
+type reviewThreadsFetchedMsg struct {
+	identifier string
+	threads    []prReviewThread
+	err        error
+}
+
+type reviewThreadUpdatedMsg struct {
+	identifier string
+	host       string
+	repoOwner  string
+	repoName   string
+	prNumber   int
+	action     string
+	err        error
+}
+
+type repoMetadataOptionsFetchedMsg struct {
+	identifier string
+	host       string
+	repoOwner  string
+	repoName   string
+	kind       prMetadataKind
+	options    repoMetadataOptions
+	err        error
+}
+
+type prMetadataEditedMsg struct {
+	identifier string
+	edit       prMetadataEdit
+	revert     prMetadataEdit
+	err        error
+}
+
+type checkRunFetchedMsg struct {
+	checkRunID string
+	details    checkRunDetails
+	err        error
+}
+
+type checksRerunMsg struct {
+	identifier string
+	host       string
+	repoOwner  string
+	repoName   string
+	prNumber   int
+	numRerun   int
+	err        error
+}
+
+type checksPollMsg struct {
+	identifier string
+	repoOwner  string
+	repoName   string
+	prNumber   int
+}
+
+type checksPolledMsg struct {
+	identifier string
+	prNumber   int
+	details    prDetails
+	err        error
+}
+
+type morePRDetailsFetchedMsg struct {
+	identifier string
+	section    PRDetailSection
+	files      prFiles
+	commits    prCommits
+	comments   prComments
+	err        error
+}
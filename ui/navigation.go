@@ -24,6 +24,9 @@ func (m *Model) setPRDetailsContent(prDetails prDetails, section PRDetailSection
 		content += prDetails.Description()
 	case PRChecks:
 		content += prDetails.Checks()
+		if prRes, ok := m.prsList.SelectedItem().(*prResult); ok && m.checksPolls[prRes.identifier] != nil {
+			content += fmt.Sprintf("\n\n> re-running failed checks; refreshing every %s", checksPollInterval)
+		}
 	case PRReferences:
 		content += prDetails.References()
 	case PRFilesChanged:
@@ -34,6 +37,10 @@ func (m *Model) setPRDetailsContent(prDetails prDetails, section PRDetailSection
 		content += prDetails.CommentsList()
 	}
 
+	if prDetails.hasMorePages(section) {
+		content += fmt.Sprintf("\n\n> press m to fetch %s", section.pagedItemsLabel())
+	}
+
 	glErr := true
 	if m.mdRenderer != nil {
 		contentGl, err := m.mdRenderer.Render(content)
@@ -82,6 +89,29 @@ func (m *Model) setPRDetailsContent(prDetails prDetails, section PRDetailSection
 	m.prDetailsVP.GotoTop()
 }
 
+// refreshShownPRDetails re-renders the details view if it's showing the PR
+// with identifier.
+func (m *Model) refreshShownPRDetails(identifier string) {
+	if m.activePane != prDetailsView {
+		return
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok || prRes.identifier != identifier {
+		return
+	}
+
+	details, ok := m.prDetailsCache[identifier]
+	if !ok {
+		return
+	}
+
+	// keep the reader's place in the section
+	offset := m.prDetailsVP.YOffset()
+	m.setPRDetailsContent(details, PRDetailsSectionList[m.prDetailsCurrentSection])
+	m.prDetailsVP.SetYOffset(offset)
+}
+
 func (m *Model) GoToPRDetailSection(section uint) {
 	if m.prDetailsCurrentSection == section {
 		return
@@ -91,7 +121,7 @@ func (m *Model) GoToPRDetailSection(section uint) {
 		return
 	}
 
-	prDetails, ok := m.prDetailsCache[fmt.Sprintf("%s/%s:%d", pr.pr.Repository.Owner.Login, pr.pr.Repository.Name, pr.pr.Number)]
+	prDetails, ok := m.prDetailsCache[pr.identifier]
 	if !ok {
 		return
 	}
@@ -147,7 +177,15 @@ func (m *Model) setPRReviewCmt(tlItem *prTLItem, commentNum uint) {
 		outdated = " `(outdated)`"
 	}
 
-	content := fmt.Sprintf("# from @%s\n## %s%s\n%s\n```diff\n%s\n```", tlItem.PullRequestReview.Author.Login, revCmts[commentNum].Path, outdated, revCmts[commentNum].Body, revCmts[commentNum].DiffHunk)
+	var resolved, replies string
+	if thread := m.getCurrentReviewThread(); thread != nil && m.prRevCurCmtNum == commentNum {
+		if thread.IsResolved {
+			resolved = " `(resolved)`"
+		}
+		replies = getReviewThreadReplies(thread, revCmts[commentNum].ID)
+	}
+
+	content := fmt.Sprintf("# from @%s\n## %s%s%s\n%s\n```diff\n%s\n```%s", tlItem.PullRequestReview.Author.Login, revCmts[commentNum].Path, outdated, resolved, revCmts[commentNum].Body, revCmts[commentNum].DiffHunk, replies)
 
 	glErr := true
 	if m.mdRenderer != nil {
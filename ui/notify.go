@@ -0,0 +1,231 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// NotificationEvent is something that happens to a PR that a notification can
+// be sent for.
+type NotificationEvent uint
+
+const (
+	PRApproved NotificationEvent = iota
+	PRChangesRequested
+	PRChecksFailed
+	PRMerged
+)
+
+func (e NotificationEvent) label() string {
+	switch e {
+	case PRChangesRequested:
+		return "changes requested"
+	case PRChecksFailed:
+		return "checks failed"
+	case PRMerged:
+		return "merged"
+	default:
+		return "approved"
+	}
+}
+
+type NotificationMethod uint
+
+const (
+	// AutoNotification uses notify-send if it's available, and OSC 9
+	// otherwise
+	AutoNotification NotificationMethod = iota
+	NotifySendNotification
+	OSC9Notification
+)
+
+// NotificationRule sends notifications for Events on PRs in Repos (as
+// owner/name), authored by Authors; empty Repos (or Authors) match every repo
+// (or author).
+type NotificationRule struct {
+	Events  []NotificationEvent
+	Repos   []string
+	Authors []string
+}
+
+type Notifications struct {
+	Method NotificationMethod
+	Rules  []NotificationRule
+}
+
+type prEvent struct {
+	event NotificationEvent
+	// actor is who caused the event, if it's known
+	actor string
+}
+
+func (n *Notifications) matches(p *pr, event NotificationEvent) bool {
+	repo := fmt.Sprintf("%s/%s", p.Repository.Owner.Login, p.Repository.Name)
+	for _, rule := range n.Rules {
+		if !slices.Contains(rule.Events, event) {
+			continue
+		}
+		if len(rule.Repos) > 0 && !slices.Contains(rule.Repos, repo) {
+			continue
+		}
+		if len(rule.Authors) > 0 && !slices.Contains(rule.Authors, p.Author.Login) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// getNewTLEvents returns the events in cur that happened after the latest item
+// in prev.
+func getNewTLEvents(prev, cur []prTLItem) []prEvent {
+	var latest time.Time
+	for _, item := range prev {
+		if t := item.createdAt(); t.After(latest) {
+			latest = t
+		}
+	}
+
+	var events []prEvent
+	for _, item := range cur {
+		if !item.createdAt().After(latest) {
+			continue
+		}
+
+		switch item.Type {
+		case tlItemPRReview:
+			switch item.PullRequestReview.State {
+			case reviewApproved:
+				events = append(events, prEvent{PRApproved, item.PullRequestReview.Author.Login})
+			case reviewChangesRequested:
+				events = append(events, prEvent{PRChangesRequested, item.PullRequestReview.Author.Login})
+			}
+		case tlItemMergedEvent:
+			events = append(events, prEvent{PRMerged, item.MergedEvent.Actor.Login})
+		}
+	}
+	return events
+}
+
+// notifyPREvents sends notifications for the events on p that match the
+// configured rules.
+func (m *Model) notifyPREvents(p *pr, events []prEvent) tea.Cmd {
+	if m.config.Notifications == nil {
+		return nil
+	}
+
+	var cmds []tea.Cmd
+	for _, e := range events {
+		if !m.config.Notifications.matches(p, e.event) {
+			continue
+		}
+
+		title := fmt.Sprintf("%s/%s#%d %s", p.Repository.Owner.Login, p.Repository.Name, p.Number, e.event.label())
+		if e.actor != "" {
+			title = fmt.Sprintf("%s by @%s", title, e.actor)
+		}
+		cmds = append(cmds, sendNotification(m.config.Notifications.Method, title, p.PRTitle))
+	}
+	return tea.Batch(cmds...)
+}
+
+func sendNotification(method NotificationMethod, title, body string) tea.Cmd {
+	if method == AutoNotification {
+		method = OSC9Notification
+		if _, err := exec.LookPath("notify-send"); err == nil {
+			method = NotifySendNotification
+		}
+	}
+
+	if method == OSC9Notification {
+		return tea.Raw(fmt.Sprintf("\x1b]9;%s: %s\x07", stripControlChars(title), stripControlChars(body)))
+	}
+
+	return func() tea.Msg {
+		err := exec.Command("notify-send", "--app-name=prs", title, body).Run()
+		return notificationSentMsg{err}
+	}
+}
+
+// stripControlChars removes control characters from s; PR titles are written
+// by anyone who can open a PR, and a BEL or ESC in one would end an escape
+// sequence early, and have the terminal act on what follows.
+func stripControlChars(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// checkDroppedPRs returns a command to look up the PRs in prev that aren't in
+// the PR list anymore, so that a notification can be sent for the ones that
+// were merged; queries like "state:open" drop merged PRs before their timeline
+// can be fetched again.
+func (m *Model) checkDroppedPRs(prev []*prResult) tea.Cmd {
+	if m.config.Notifications == nil {
+		return nil
+	}
+
+	cur := make(map[string]bool, len(m.prCache))
+	for _, prRes := range m.prCache {
+		cur[prRes.identifier] = true
+	}
+
+	var cmds []tea.Cmd
+	for _, prRes := range prev {
+		if cur[prRes.identifier] || !m.config.Notifications.matches(prRes.pr, PRMerged) {
+			continue
+		}
+
+		// a notification has been sent already if the merge made it to the
+		// PR's timeline
+		merged := slices.ContainsFunc(m.prTLCache[prRes.identifier], func(r *prTLItemResult) bool {
+			return r.item.Type == tlItemMergedEvent
+		})
+		if merged {
+			continue
+		}
+
+		cmds = append(cmds, fetchDroppedPR(m.prSourceForHost(getPRHost(prRes.pr)), *prRes.pr))
+	}
+	return tea.Batch(cmds...)
+}
+
+func fetchDroppedPR(prSource prDataSource, p pr) tea.Cmd {
+	return func() tea.Msg {
+		details, err := prSource.GetPRDetails(p.Repository.Owner.Login, p.Repository.Name, p.Number)
+		return droppedPRFetchedMsg{p, details, err}
+	}
+}
+
+func (m *Model) notifyNewTLEvents(identifier string, prev []*prTLItemResult, cur []prTLItem) tea.Cmd {
+	if m.config.Notifications == nil {
+		return nil
+	}
+
+	var prRes *prResult
+	for _, r := range m.prCache {
+		if r.identifier == identifier {
+			prRes = r
+			break
+		}
+	}
+	if prRes == nil {
+		return nil
+	}
+
+	prevItems := make([]prTLItem, len(prev))
+	for i, r := range prev {
+		prevItems[i] = *r.item
+	}
+
+	return m.notifyPREvents(prRes.pr, getNewTLEvents(prevItems, cur))
+}
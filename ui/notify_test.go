@@ -0,0 +1,139 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func reviewTLItem(state, author string, createdAt time.Time) prTLItem {
+	var item prTLItem
+	item.Type = tlItemPRReview
+	item.PullRequestReview.State = state
+	item.PullRequestReview.Author.Login = author
+	item.PullRequestReview.CreatedAt = createdAt
+	return item
+}
+
+func TestGetNewTLEvents(t *testing.T) {
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	var merged prTLItem
+	merged.Type = tlItemMergedEvent
+	merged.MergedEvent.Actor.Login = "dhth"
+	merged.MergedEvent.CreatedAt = start.Add(3 * time.Hour)
+
+	prev := []prTLItem{reviewTLItem(reviewApproved, "old", start)}
+	cur := []prTLItem{
+		reviewTLItem(reviewApproved, "old", start),
+		reviewTLItem(reviewCommented, "someone", start.Add(time.Hour)),
+		reviewTLItem(reviewChangesRequested, "reviewer", start.Add(2*time.Hour)),
+		merged,
+	}
+
+	got := getNewTLEvents(prev, cur)
+
+	assert.Equal(t, []prEvent{
+		{PRChangesRequested, "reviewer"},
+		{PRMerged, "dhth"},
+	}, got)
+}
+
+func TestNotificationRulesMatch(t *testing.T) {
+	n := &Notifications{Rules: []NotificationRule{
+		{Events: []NotificationEvent{PRApproved}, Repos: []string{"dhth/prs"}},
+		{Events: []NotificationEvent{PRMerged}, Authors: []string{"dhth"}},
+	}}
+
+	p := &pr{}
+	p.Repository.Owner.Login = "dhth"
+	p.Repository.Name = "prs"
+	p.Author.Login = "someone"
+
+	assert.True(t, n.matches(p, PRApproved))
+	assert.False(t, n.matches(p, PRMerged))
+	assert.False(t, n.matches(p, PRChecksFailed))
+
+	p.Repository.Name = "omm"
+	assert.False(t, n.matches(p, PRApproved))
+}
+
+func TestNotifyPREventsViaOSC9(t *testing.T) {
+	query := "type:pr author:@me"
+	m := InitialModel(&fakePRSource{}, Config{
+		Query: &query,
+		Notifications: &Notifications{
+			Method: OSC9Notification,
+			Rules:  []NotificationRule{{Events: []NotificationEvent{PRApproved}}},
+		},
+	}, QueryMode)
+
+	p := &pr{Number: 7, PRTitle: "Add notifications"}
+	p.Repository.Owner.Login = "dhth"
+	p.Repository.Name = "prs"
+
+	cmd := m.notifyPREvents(p, []prEvent{{PRApproved, "reviewer"}, {PRMerged, "dhth"}})
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(tea.RawMsg)
+	require.True(t, ok)
+	assert.Equal(t, "\x1b]9;dhth/prs#7 approved by @reviewer: Add notifications\x07", msg.Msg)
+}
+
+func TestNotifyingMergedPRsDroppedByRefresh(t *testing.T) {
+	query := "type:pr author:@me state:open"
+	merged := prDetails{State: prStateMerged}
+	merged.MergedBy = &struct{ Login string }{"dhth"}
+	src := &fakePRSource{details: map[int]prDetails{
+		1: merged,
+		2: {State: prStateClosed},
+	}}
+	m := InitialModel(src, Config{
+		Query: &query,
+		Notifications: &Notifications{
+			Method: OSC9Notification,
+			Rules:  []NotificationRule{{Events: []NotificationEvent{PRMerged}}},
+		},
+	}, QueryMode)
+	m.awaitingPRs = false
+
+	prs := make([]pr, 3)
+	for i := range prs {
+		prs[i] = pr{Number: i + 1, PRTitle: "Add notifications"}
+		prs[i].Repository.Owner.Login = "dhth"
+		prs[i].Repository.Name = "prs"
+	}
+	m.setPRs(prs)
+
+	prev := m.prCache
+	m.setRefreshedPRs(prs[2:])
+
+	batch, ok := m.checkDroppedPRs(prev)().(tea.BatchMsg)
+	require.True(t, ok)
+	require.Len(t, batch, 2)
+
+	var notifications []string
+	for _, cmd := range batch {
+		updated, notifyCmd := m.Update(cmd())
+		m = updated.(Model)
+		if notifyCmd == nil {
+			continue
+		}
+		msg, ok := notifyCmd().(tea.RawMsg)
+		require.True(t, ok)
+		notifications = append(notifications, msg.Msg.(string))
+	}
+
+	assert.Equal(t, []string{"\x1b]9;dhth/prs#1 merged by @dhth: Add notifications\x07"}, notifications)
+}
+
+func TestOSC9NotificationsLeaveOutControlChars(t *testing.T) {
+	cmd := sendNotification(OSC9Notification, "dhth/prs#7 approved", "title\x07\x1b]9;injected\x1b\\")
+
+	msg, ok := cmd().(tea.RawMsg)
+	require.True(t, ok)
+	assert.Equal(t, "\x1b]9;dhth/prs#7 approved: title]9;injected\\\x07", msg.Msg)
+}
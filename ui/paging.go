@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"fmt"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// pagedItemsLabel describes what fetching another page of a section of the
+// details view brings in; sections that can't be paged through have none.
+func (s PRDetailSection) pagedItemsLabel() string {
+	switch s {
+	case PRFilesChanged:
+		return "more files"
+	case PRCommits:
+		return "earlier commits"
+	case PRComments:
+		return "more comments"
+	default:
+		return ""
+	}
+}
+
+// hasMorePages reports whether there's more to fetch for a section of the
+// details view.
+func (pr prDetails) hasMorePages(section PRDetailSection) bool {
+	switch section {
+	case PRFilesChanged:
+		return pr.Files.PageInfo.HasNextPage && pr.Files.PageInfo.EndCursor != nil
+	case PRCommits:
+		return pr.Commits.PageInfo.HasPreviousPage && pr.Commits.PageInfo.StartCursor != nil
+	case PRComments:
+		return pr.Comments.PageInfo.HasNextPage && pr.Comments.PageInfo.EndCursor != nil
+	default:
+		return false
+	}
+}
+
+// fetchMorePRDetails returns a command to fetch the next page of the section
+// shown in the details view, if there's more to it.
+func (m *Model) fetchMorePRDetails() tea.Cmd {
+	if m.fetchingMorePRDetails {
+		return nil
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	details, ok := m.prDetailsCache[prRes.identifier]
+	if !ok {
+		return nil
+	}
+
+	section := PRDetailsSectionList[m.prDetailsCurrentSection]
+	if section.pagedItemsLabel() == "" {
+		return nil
+	}
+	if !details.hasMorePages(section) {
+		m.message = fmt.Sprintf("there are no %s", section.pagedItemsLabel())
+		return nil
+	}
+
+	var cursor *string
+	switch section {
+	case PRFilesChanged:
+		cursor = details.Files.PageInfo.EndCursor
+	case PRCommits:
+		cursor = details.Commits.PageInfo.StartCursor
+	case PRComments:
+		cursor = details.Comments.PageInfo.EndCursor
+	}
+
+	m.fetchingMorePRDetails = true
+	m.message = fmt.Sprintf("fetching %s...", section.pagedItemsLabel())
+
+	return fetchMorePRDetailsPage(m.prSourceForHost(getPRHost(prRes.pr)),
+		prRes.identifier,
+		prRes.pr.Repository.Owner.Login,
+		prRes.pr.Repository.Name,
+		prRes.pr.Number,
+		section,
+		cursor,
+	)
+}
+
+func fetchMorePRDetailsPage(prSource prDataSource, identifier, repoOwner, repoName string, prNumber int, section PRDetailSection, cursor *string) tea.Cmd {
+	return func() tea.Msg {
+		msg := morePRDetailsFetchedMsg{identifier: identifier, section: section}
+
+		pager, ok := prSource.(prDetailsPager)
+		if !ok {
+			msg.err = errActionNotSupported
+			return msg
+		}
+
+		switch section {
+		case PRFilesChanged:
+			msg.files, msg.err = pager.GetPRFiles(repoOwner, repoName, prNumber, cursor)
+		case PRCommits:
+			msg.commits, msg.err = pager.GetPRCommits(repoOwner, repoName, prNumber, cursor)
+		case PRComments:
+			msg.comments, msg.err = pager.GetPRComments(repoOwner, repoName, prNumber, cursor)
+		}
+		return msg
+	}
+}
+
+// addMorePRDetails adds the page in msg to the cached details of its PR, and
+// shows it if the PR's details are being shown. Commits are fetched backwards,
+// so they go before the ones already there.
+func (m *Model) addMorePRDetails(msg morePRDetailsFetchedMsg) {
+	details, ok := m.prDetailsCache[msg.identifier]
+	if !ok {
+		return
+	}
+
+	switch msg.section {
+	case PRFilesChanged:
+		msg.files.Nodes = append(details.Files.Nodes, msg.files.Nodes...)
+		details.Files = msg.files
+	case PRCommits:
+		msg.commits.Nodes = append(msg.commits.Nodes, details.Commits.Nodes...)
+		details.Commits = msg.commits
+	case PRComments:
+		msg.comments.Nodes = append(details.Comments.Nodes, msg.comments.Nodes...)
+		details.Comments = msg.comments
+	}
+
+	m.prDetailsCache[msg.identifier] = details
+	m.refreshShownPRDetails(msg.identifier)
+}
+
+// keepPagedInPRDetails carries the pages fetched for a PR's details in prev
+// over to cur, its details as fetched again. Files and commits are only kept
+// if the PR's head hasn't changed since; comments are kept regardless, as
+// comments fetched earlier are still the first ones on the PR.
+func keepPagedInPRDetails(prev, cur prDetails) prDetails {
+	if prev.headOid() != "" && prev.headOid() == cur.headOid() {
+		if len(prev.Files.Nodes) > len(cur.Files.Nodes) {
+			cur.Files = prev.Files
+		}
+		if len(prev.Commits.Nodes) > len(cur.Commits.Nodes) {
+			cur.Commits = prev.Commits
+		}
+	}
+
+	if len(prev.Comments.Nodes) > len(cur.Comments.Nodes) {
+		comments := prev.Comments
+		comments.TotalCount = cur.Comments.TotalCount
+		comments.PageInfo.HasNextPage = cur.Comments.TotalCount > len(comments.Nodes)
+		cur.Comments = comments
+	}
+
+	return cur
+}
+
+// headOid returns the abbreviated hash of a PR's head commit, if it's known.
+func (pr prDetails) headOid() string {
+	if len(pr.LastCommit.Nodes) == 0 {
+		return ""
+	}
+	return pr.LastCommit.Nodes[0].Commit.AbbreviatedOid
+}
@@ -0,0 +1,194 @@
+package ui
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePagingSource struct {
+	fakePRSource
+	files       prFiles
+	commits     prCommits
+	lastCursors []string
+}
+
+func (s *fakePagingSource) GetPRFiles(_, _ string, _ int, after *string) (prFiles, error) {
+	s.lastCursors = append(s.lastCursors, *after)
+	return s.files, nil
+}
+
+func (s *fakePagingSource) GetPRCommits(_, _ string, _ int, before *string) (prCommits, error) {
+	s.lastCursors = append(s.lastCursors, *before)
+	return s.commits, nil
+}
+
+func (s *fakePagingSource) GetPRComments(_, _ string, _ int, _ *string) (prComments, error) {
+	return prComments{}, nil
+}
+
+func getTestPRFiles(paths ...string) prFiles {
+	var files prFiles
+	files.TotalCount = 3
+	files.Nodes = slices.Grow(files.Nodes, len(paths))[:len(paths)]
+	for i, path := range paths {
+		files.Nodes[i].Path = path
+	}
+	return files
+}
+
+func getTestPRCommits(hashes ...string) prCommits {
+	var commits prCommits
+	commits.TotalCount = 3
+	commits.Nodes = slices.Grow(commits.Nodes, len(hashes))[:len(hashes)]
+	for i, hash := range hashes {
+		commits.Nodes[i].Commit.AbbreviatedOid = hash
+	}
+	return commits
+}
+
+func TestFetchingMorePRDetails(t *testing.T) {
+	src := &fakePagingSource{
+		files:   getTestPRFiles("c.go"),
+		commits: getTestPRCommits("aaa"),
+	}
+	m := newTestModel(t, src, pr{Number: 1})
+
+	filesCursor := "ZmlsZXM="
+	commitsCursor := "Y29tbWl0cw=="
+	details := prDetails{Number: 1, Files: getTestPRFiles("a.go", "b.go"), Commits: getTestPRCommits("bbb", "ccc")}
+	details.Files.PageInfo = pageInfo{HasNextPage: true, EndCursor: &filesCursor}
+	details.Commits.PageInfo = tlPageInfo{HasPreviousPage: true, StartCursor: &commitsCursor}
+
+	identifier := m.prCache[0].identifier
+	m.prDetailsCache[identifier] = details
+	m.activePane = prDetailsView
+
+	fetchMore := func() {
+		t.Helper()
+		updated, cmd := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+		m = updated.(Model)
+		require.NotNil(t, cmd)
+
+		msg, ok := cmd().(morePRDetailsFetchedMsg)
+		require.True(t, ok)
+		updated, _ = m.Update(msg)
+		m = updated.(Model)
+	}
+
+	// the metadata section can't be paged through
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	m = updated.(Model)
+	assert.Nil(t, cmd)
+
+	m.GoToPRDetailSection(uint(PRFilesChanged))
+	assert.Contains(t, m.prDetailsVP.GetContent(), "press m to fetch more files")
+
+	fetchMore()
+	assert.Equal(t, []string{filesCursor}, src.lastCursors)
+	var paths []string
+	for _, f := range m.prDetailsCache[identifier].Files.Nodes {
+		paths = append(paths, f.Path)
+	}
+	assert.Equal(t, []string{"a.go", "b.go", "c.go"}, paths)
+	assert.False(t, m.prDetailsCache[identifier].hasMorePages(PRFilesChanged))
+	assert.NotContains(t, m.prDetailsVP.GetContent(), "press m to fetch")
+	assert.NotContains(t, m.prDetailsVP.GetContent(), "out of")
+
+	// there's nothing more to fetch
+	updated, cmd = m.Update(tea.KeyPressMsg{Code: 'm', Text: "m"})
+	m = updated.(Model)
+	assert.Nil(t, cmd)
+	assert.Equal(t, "there are no more files", m.message)
+
+	m.GoToPRDetailSection(uint(PRCommits))
+	fetchMore()
+	assert.Equal(t, []string{filesCursor, commitsCursor}, src.lastCursors)
+	var hashes []string
+	for _, c := range m.prDetailsCache[identifier].Commits.Nodes {
+		hashes = append(hashes, c.Commit.AbbreviatedOid)
+	}
+	assert.Equal(t, []string{"aaa", "bbb", "ccc"}, hashes)
+}
+
+func TestFilesChangedIndicatesPartialResults(t *testing.T) {
+	details := prDetails{Files: getTestPRFiles("a.go", "b.go")}
+
+	assert.Contains(t, details.FilesChanged(), "## Files changed (first 2 out of 3)")
+
+	details.Files.TotalCount = 2
+	assert.NotContains(t, details.FilesChanged(), "out of")
+}
+
+func TestPrefetchKeepsPagedInDetailsAndEarlierTLItems(t *testing.T) {
+	m := newTestModel(t, &fakePRSource{}, pr{Number: 1})
+	p := m.prCache[0].pr
+	ref := prRef{getPRHost(p), p.Repository.Owner.Login, p.Repository.Name, p.Number, p.UpdatedAt, p.checksState()}
+	identifier := ref.identifier()
+
+	withHead := func(details prDetails, oid string) prDetails {
+		details.LastCommit.Nodes = make([]prLastCommitNode, 1)
+		details.LastCommit.Nodes[0].Commit.AbbreviatedOid = oid
+		return details
+	}
+	commentAt := func(createdAt time.Time) prTLItem {
+		item := getIssueCommentTLItem("octocat")
+		item.IssueComment.CreatedAt = createdAt
+		return item
+	}
+
+	start := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	earlierCursor := "ZWFybGllcg=="
+	m.prDetailsCache[identifier] = withHead(prDetails{Files: getTestPRFiles("a.go", "b.go", "c.go"), Commits: getTestPRCommits("aaa", "bbb", "ccc")}, "ccc")
+	m.prTLCache[identifier] = newPRTLItemResults([]prTLItem{commentAt(start), commentAt(start.Add(time.Hour)), commentAt(start.Add(2 * time.Hour))})
+	m.prTLPageInfoCache[identifier] = tlPageInfo{StartCursor: &earlierCursor}
+
+	latestCursor := "bGF0ZXN0"
+	refetched := prData{
+		details:    withHead(prDetails{Files: getTestPRFiles("a.go"), Commits: getTestPRCommits("ccc")}, "ccc"),
+		tlItems:    []prTLItem{commentAt(start.Add(2 * time.Hour)), commentAt(start.Add(3 * time.Hour))},
+		tlPageInfo: tlPageInfo{HasPreviousPage: true, StartCursor: &latestCursor},
+	}
+
+	m.prefetchInFlight = 1
+	updated, _ := m.Update(prsPrefetchedMsg{[]prRef{ref}, []prData{refetched}, nil})
+	m = updated.(Model)
+
+	details := m.prDetailsCache[identifier]
+	assert.Len(t, details.Files.Nodes, 3)
+	assert.Len(t, details.Commits.Nodes, 3)
+	require.Len(t, m.prTLCache[identifier], 4)
+	assert.Equal(t, start, m.prTLCache[identifier][0].item.createdAt())
+	assert.Equal(t, &earlierCursor, m.prTLPageInfoCache[identifier].StartCursor)
+
+	// files and commits fetched for an earlier head are dropped
+	m.prefetchInFlight = 1
+	refetched.details = withHead(refetched.details, "ddd")
+	updated, _ = m.Update(prsPrefetchedMsg{[]prRef{ref}, []prData{refetched}, nil})
+	m = updated.(Model)
+
+	details = m.prDetailsCache[identifier]
+	assert.Len(t, details.Files.Nodes, 1)
+	assert.Len(t, details.Commits.Nodes, 1)
+}
+
+func TestKeepPagedInPRDetailsKeepsMoreComments(t *testing.T) {
+	var prev, cur prDetails
+	prev.Comments.Nodes = slices.Grow(prev.Comments.Nodes, 3)[:3]
+	cur.Comments.Nodes = prev.Comments.Nodes[:1]
+	cur.Comments.TotalCount = 4
+	cur.Comments.PageInfo.HasNextPage = true
+
+	got := keepPagedInPRDetails(prev, cur)
+	assert.Len(t, got.Comments.Nodes, 3)
+	assert.Equal(t, 4, got.Comments.TotalCount)
+	assert.True(t, got.Comments.PageInfo.HasNextPage)
+
+	cur.Comments.TotalCount = 3
+	got = keepPagedInPRDetails(prev, cur)
+	assert.False(t, got.Comments.PageInfo.HasNextPage)
+}
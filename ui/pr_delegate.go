@@ -1,11 +1,42 @@
 package ui
 
 import (
+	"fmt"
+	"io"
+
 	"charm.land/bubbles/v2/list"
 	"charm.land/lipgloss/v2"
 )
 
-func newPRListItemDel() list.DefaultDelegate {
+// prListItemDel renders PRs the same way as list.DefaultDelegate, and
+// additionally renders group headers.
+type prListItemDel struct {
+	list.DefaultDelegate
+}
+
+func (d prListItemDel) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	header, ok := item.(prGroupHeader)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	titleStyle := repoGroupHeaderStyle
+	if header.dynamic {
+		titleStyle = titleStyle.Foreground(getDynamicStyle(header.name).GetForeground())
+	}
+
+	if index == m.Index() {
+		titleStyle = titleStyle.Foreground(lipgloss.Color(prListColor))
+	}
+
+	fmt.Fprintf(w, "%s\n%s",
+		titleStyle.Render(header.Title()),
+		repoGroupCountStyle.Render(header.Description()),
+	)
+}
+
+func newPRListItemDel() prListItemDel {
 	d := list.NewDefaultDelegate()
 
 	d.Styles.SelectedTitle = d.Styles.
@@ -15,5 +46,5 @@ func newPRListItemDel() list.DefaultDelegate {
 	d.Styles.SelectedDesc = d.Styles.
 		SelectedTitle
 
-	return d
+	return prListItemDel{d}
 }
@@ -0,0 +1,148 @@
+package ui
+
+import (
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const prefetchTLItemsCount = 100
+
+// DefaultPrefetchWorkers and DefaultPrefetchBatchSize are used when Config
+// leaves PrefetchWorkers and PrefetchBatchSize unset.
+const (
+	DefaultPrefetchWorkers   = 4
+	DefaultPrefetchBatchSize = 5
+)
+
+type prRef struct {
+	host        string
+	repoOwner   string
+	repoName    string
+	prNumber    int
+	updatedAt   time.Time
+	checksState string
+}
+
+func (p prRef) identifier() string {
+	return getPRIdentifier(p.host, p.repoOwner, p.repoName, p.prNumber)
+}
+
+type prData struct {
+	details    prDetails
+	tlItems    []prTLItem
+	tlPageInfo tlPageInfo
+}
+
+// enqueuePrefetch adds the given PRs to the prefetch queue, and starts as many
+// prefetch workers as allowed.
+func (m *Model) enqueuePrefetch(prs []pr) tea.Cmd {
+	for _, pr := range prs {
+		m.prefetchQueue = append(m.prefetchQueue, prRef{getPRHost(&pr), pr.Repository.Owner.Login, pr.Repository.Name, pr.Number, pr.UpdatedAt, pr.checksState()})
+	}
+
+	return m.dispatchPrefetch()
+}
+
+// dispatchPrefetch hands batches from the prefetch queue to workers, keeping
+// the number of in-flight requests within the configured limit.
+func (m *Model) dispatchPrefetch() tea.Cmd {
+	workers := m.config.PrefetchWorkers
+	if workers <= 0 {
+		workers = DefaultPrefetchWorkers
+	}
+	batchSize := m.config.PrefetchBatchSize
+	if batchSize <= 0 {
+		batchSize = DefaultPrefetchBatchSize
+	}
+
+	var cmds []tea.Cmd
+	for m.prefetchInFlight < workers && len(m.prefetchQueue) > 0 {
+		// a batch is fetched in one request, so it only holds PRs on one host
+		n := 1
+		for n < min(batchSize, len(m.prefetchQueue)) && m.prefetchQueue[n].host == m.prefetchQueue[0].host {
+			n++
+		}
+		batch := m.prefetchQueue[:n]
+		m.prefetchQueue = m.prefetchQueue[n:]
+		m.prefetchInFlight++
+
+		cmds = append(cmds, prefetchPRs(m.prSourceForHost(batch[0].host), m.diskCache, batch))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// prefetchPRs fetches details and timelines for the given PRs, using data from
+// the disk cache for PRs that haven't been updated since they were cached.
+func prefetchPRs(prSource prDataSource, cache *diskCache, prs []prRef) tea.Cmd {
+	return func() tea.Msg {
+		data := make([]prData, len(prs))
+		available := make([]bool, len(prs))
+		var toFetch []prRef
+		var toFetchIndices []int
+
+		for i, p := range prs {
+			cached, ok := cache.getPR(p)
+			if ok {
+				data[i] = cached
+				available[i] = true
+				continue
+			}
+			toFetch = append(toFetch, p)
+			toFetchIndices = append(toFetchIndices, i)
+		}
+
+		if len(toFetch) == 0 {
+			return prsPrefetchedMsg{prs, data, nil}
+		}
+
+		fetched, err := fetchPRsData(prSource, toFetch)
+		for j, d := range fetched {
+			data[toFetchIndices[j]] = d
+			available[toFetchIndices[j]] = true
+			_ = cache.savePR(toFetch[j], d)
+		}
+
+		if err == nil {
+			return prsPrefetchedMsg{prs, data, nil}
+		}
+
+		// only hand over PRs whose data is actually available
+		var availablePRs []prRef
+		var availableData []prData
+		for i, p := range prs {
+			if available[i] {
+				availablePRs = append(availablePRs, p)
+				availableData = append(availableData, data[i])
+			}
+		}
+		return prsPrefetchedMsg{availablePRs, availableData, err}
+	}
+}
+
+// fetchPRsData fetches data for the given PRs from the source, in a single
+// request if the source supports it. On error, data for the PRs fetched
+// before the failure is returned along with the error.
+func fetchPRsData(prSource prDataSource, prs []prRef) ([]prData, error) {
+	if bs, ok := prSource.(batchPRSource); ok {
+		return bs.GetPRsData(prs, prefetchTLItemsCount)
+	}
+
+	data := make([]prData, 0, len(prs))
+	for _, p := range prs {
+		details, err := prSource.GetPRDetails(p.repoOwner, p.repoName, p.prNumber)
+		if err != nil {
+			return data, err
+		}
+
+		tlItems, tlPageInfo, err := prSource.GetPRTimeline(p.repoOwner, p.repoName, p.prNumber, prefetchTLItemsCount, nil)
+		if err != nil {
+			return data, err
+		}
+
+		data = append(data, prData{details, tlItems, tlPageInfo})
+	}
+
+	return data, nil
+}
@@ -0,0 +1,187 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	ghapi "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/dustin/go-humanize"
+)
+
+const (
+	rateLimitMaxRetries    = 3
+	rateLimitBaseBackoff   = 2 * time.Second
+	rateLimitMaxBackoff    = time.Minute
+	rateLimitLowThreshold  = 0.1
+	graphQLRateLimitedType = "RATE_LIMITED"
+)
+
+var errRateLimitExhausted = errors.New("API rate limit exhausted")
+
+type rateLimit struct {
+	Limit     int
+	Cost      int
+	Remaining int
+	ResetAt   time.Time
+}
+
+func (rl rateLimit) isLow() bool {
+	return rl.Limit > 0 && float64(rl.Remaining) < float64(rl.Limit)*rateLimitLowThreshold
+}
+
+type graphQLQuerier interface {
+	Query(name string, q any, variables map[string]any) error
+	Mutate(name string, m any, variables map[string]any) error
+}
+
+// rateLimitedClient wraps a GraphQL client, keeping track of the rate limit
+// budget Github reports for every query, and retrying requests that run into
+// secondary rate limits.
+//
+// Queries that want their budget tracked need to have a top level RateLimit
+// field of type rateLimit.
+type rateLimitedClient struct {
+	client    graphQLQuerier
+	sleep     func(time.Duration)
+	mu        sync.Mutex
+	rateLimit *rateLimit
+}
+
+func newRateLimitedClient(client graphQLQuerier) *rateLimitedClient {
+	return &rateLimitedClient{client: client, sleep: time.Sleep}
+}
+
+func (c *rateLimitedClient) Query(name string, q any, variables map[string]any) error {
+	return c.do(q, func() error {
+		return c.client.Query(name, q, variables)
+	})
+}
+
+// Mutate runs a mutation; mutations that run into secondary rate limits are
+// retried as well, since Github rejects those before acting on them.
+func (c *rateLimitedClient) Mutate(name string, m any, variables map[string]any) error {
+	return c.do(m, func() error {
+		return c.client.Mutate(name, m, variables)
+	})
+}
+
+func (c *rateLimitedClient) do(q any, run func() error) error {
+	if rl := c.getRateLimit(); rl != nil && rl.Remaining == 0 && time.Now().Before(rl.ResetAt) {
+		return fmt.Errorf("%w; resets %s", errRateLimitExhausted, humanize.Time(rl.ResetAt))
+	}
+
+	var err error
+	for attempt := 0; ; attempt++ {
+		err = run()
+		if err == nil {
+			c.recordRateLimit(q)
+			return nil
+		}
+
+		if isPrimaryRateLimitErr(err) {
+			if rl := c.getRateLimit(); rl != nil {
+				return fmt.Errorf("%w; resets %s", errRateLimitExhausted, humanize.Time(rl.ResetAt))
+			}
+			return fmt.Errorf("%w: %s", errRateLimitExhausted, err.Error())
+		}
+
+		wait, ok := getRetryWait(err, attempt)
+		if !ok || attempt >= rateLimitMaxRetries {
+			return err
+		}
+		c.sleep(wait)
+	}
+}
+
+func (c *rateLimitedClient) getRateLimit() *rateLimit {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.rateLimit == nil {
+		return nil
+	}
+	rl := *c.rateLimit
+	return &rl
+}
+
+func (c *rateLimitedClient) recordRateLimit(q any) {
+	v := reflect.ValueOf(q)
+	if v.Kind() != reflect.Pointer || v.Elem().Kind() != reflect.Struct {
+		return
+	}
+
+	f := v.Elem().FieldByName("RateLimit")
+	if !f.IsValid() {
+		return
+	}
+
+	rl, ok := f.Interface().(rateLimit)
+	if !ok || rl.Limit == 0 {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// responses can arrive out of order; keep the lowest budget seen for a
+	// given reset window
+	if c.rateLimit != nil && c.rateLimit.ResetAt.Equal(rl.ResetAt) && c.rateLimit.Remaining < rl.Remaining {
+		return
+	}
+	c.rateLimit = &rl
+}
+
+func isPrimaryRateLimitErr(err error) bool {
+	var gqlErr *ghapi.GraphQLError
+	if errors.As(err, &gqlErr) {
+		for _, e := range gqlErr.Errors {
+			if e.Type == graphQLRateLimitedType {
+				return true
+			}
+		}
+		return false
+	}
+
+	var httpErr *ghapi.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusForbidden &&
+			httpErr.Headers.Get("X-Ratelimit-Remaining") == "0"
+	}
+
+	return false
+}
+
+// getRetryWait reports how long to wait before retrying a query that failed
+// with err, and whether it should be retried at all. Only secondary rate limit
+// errors are retried.
+func getRetryWait(err error, attempt int) (time.Duration, bool) {
+	var httpErr *ghapi.HTTPError
+	if !errors.As(err, &httpErr) {
+		return 0, false
+	}
+
+	if httpErr.StatusCode != http.StatusForbidden && httpErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	retryAfter := httpErr.Headers.Get("Retry-After")
+	if retryAfter != "" {
+		secs, err := strconv.Atoi(retryAfter)
+		if err == nil {
+			return min(time.Duration(secs)*time.Second, rateLimitMaxBackoff), true
+		}
+	}
+
+	if httpErr.StatusCode == http.StatusForbidden &&
+		!strings.Contains(strings.ToLower(httpErr.Message), "secondary rate limit") {
+		return 0, false
+	}
+
+	return min(rateLimitBaseBackoff<<attempt, rateLimitMaxBackoff), true
+}
@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	ghapi "github.com/cli/go-gh/v2/pkg/api"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeQuerier struct {
+	errs  []error
+	calls int
+}
+
+func (q *fakeQuerier) Query(_ string, query any, _ map[string]any) error {
+	q.calls++
+	if len(q.errs) > 0 {
+		err := q.errs[0]
+		q.errs = q.errs[1:]
+		return err
+	}
+
+	if sq, ok := query.(*prSearchQuery); ok {
+		sq.RateLimit = rateLimit{Limit: 5000, Cost: 1, Remaining: 4200, ResetAt: time.Now().Add(time.Hour)}
+	}
+	return nil
+}
+
+func (q *fakeQuerier) Mutate(name string, m any, variables map[string]any) error {
+	return q.Query(name, m, variables)
+}
+
+func TestRateLimitedClientRetriesSecondaryRateLimits(t *testing.T) {
+	secondaryErr := &ghapi.HTTPError{
+		StatusCode: http.StatusForbidden,
+		Message:    "You have exceeded a secondary rate limit. Please wait a few minutes before you try again.",
+		Headers:    http.Header{},
+	}
+	querier := &fakeQuerier{errs: []error{secondaryErr, secondaryErr}}
+	client := newRateLimitedClient(querier)
+	var waits []time.Duration
+	client.sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	var query prSearchQuery
+	err := client.Query("PRQuery", &query, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, 3, querier.calls)
+	assert.Equal(t, []time.Duration{2 * time.Second, 4 * time.Second}, waits)
+
+	rl := client.getRateLimit()
+	require.NotNil(t, rl)
+	assert.Equal(t, 4200, rl.Remaining)
+}
+
+func TestRateLimitedClientHonoursRetryAfter(t *testing.T) {
+	querier := &fakeQuerier{errs: []error{&ghapi.HTTPError{
+		StatusCode: http.StatusTooManyRequests,
+		Headers:    http.Header{"Retry-After": []string{"7"}},
+	}}}
+	client := newRateLimitedClient(querier)
+	var waits []time.Duration
+	client.sleep = func(d time.Duration) { waits = append(waits, d) }
+
+	var query prSearchQuery
+	err := client.Query("PRQuery", &query, nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, []time.Duration{7 * time.Second}, waits)
+}
+
+func TestRateLimitedClientDoesntRetryOtherErrors(t *testing.T) {
+	querier := &fakeQuerier{errs: []error{&ghapi.HTTPError{
+		StatusCode: http.StatusForbidden,
+		Message:    "Resource not accessible by integration",
+		Headers:    http.Header{},
+	}}}
+	client := newRateLimitedClient(querier)
+	client.sleep = func(time.Duration) { t.Fatal("shouldn't have waited") }
+
+	var query prSearchQuery
+	err := client.Query("PRQuery", &query, nil)
+
+	require.Error(t, err)
+	assert.Equal(t, 1, querier.calls)
+}
+
+func TestRateLimitedClientReportsExhaustedPrimaryLimit(t *testing.T) {
+	querier := &fakeQuerier{errs: []error{&ghapi.GraphQLError{
+		Errors: []ghapi.GraphQLErrorItem{{Type: graphQLRateLimitedType, Message: "API rate limit exceeded"}},
+	}}}
+	client := newRateLimitedClient(querier)
+
+	var query prSearchQuery
+	err := client.Query("PRQuery", &query, nil)
+
+	require.ErrorIs(t, err, errRateLimitExhausted)
+	assert.Equal(t, 1, querier.calls)
+}
@@ -0,0 +1,132 @@
+package ui
+
+import (
+	"strings"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// prChanges records what changed about a PR between two refreshes.
+type prChanges uint8
+
+const (
+	prChangeNew prChanges = 1 << iota
+	prChangeCommits
+	prChangeReviews
+	prChangeChecks
+	prChangeChecksFailed
+)
+
+func (c prChanges) String() string {
+	if c&prChangeNew != 0 {
+		return "new"
+	}
+
+	var changes []string
+	if c&prChangeCommits != 0 {
+		changes = append(changes, "new commits")
+	}
+	if c&prChangeReviews != 0 {
+		changes = append(changes, "new reviews")
+	}
+	if c&prChangeChecksFailed != 0 {
+		changes = append(changes, "checks failed")
+	} else if c&prChangeChecks != 0 {
+		changes = append(changes, "checks changed")
+	}
+	return strings.Join(changes, ", ")
+}
+
+func getPRChanges(prev, cur *pr) prChanges {
+	if prev == nil {
+		return prChangeNew
+	}
+
+	if !cur.UpdatedAt.After(prev.UpdatedAt) && cur.checksState() == prev.checksState() {
+		return 0
+	}
+
+	var changes prChanges
+	if cur.Commits.TotalCount > prev.Commits.TotalCount {
+		changes |= prChangeCommits
+	}
+	if cur.Reviews.TotalCount > prev.Reviews.TotalCount {
+		changes |= prChangeReviews
+	}
+	// check runs finishing doesn't bump a PR's updatedAt, so this is
+	// compared regardless
+	if cur.checksState() != prev.checksState() {
+		changes |= prChangeChecks
+		if cur.checksFailed() {
+			changes |= prChangeChecksFailed
+		}
+	}
+	return changes
+}
+
+func scheduleRefresh(interval time.Duration) tea.Cmd {
+	return tea.Tick(interval, func(time.Time) tea.Msg {
+		return refreshTickMsg{}
+	})
+}
+
+func refreshPRs(prSource prDataSource, queryStr string, prCount int) tea.Cmd {
+	return func() tea.Msg {
+		prs, pageInfo, err := searchAllPRs(prSource, queryStr, prCount)
+		return prsRefreshedMsg{queryStr, prs, pageInfo, err}
+	}
+}
+
+// refreshPRsIfIdle re-runs the current query in the background, unless PRs
+// are already being fetched.
+func (m *Model) refreshPRsIfIdle() tea.Cmd {
+	if m.awaitingPRs || m.fetchingMorePRs || m.prsFromCache {
+		return nil
+	}
+	if m.mode == RepoMode && !m.repoChosen {
+		return nil
+	}
+
+	// refetch as many PRs as are shown, a page at a time, so that pages
+	// fetched on scrolling aren't dropped
+	prCount := max(m.config.PRCount, len(m.prCache))
+	return refreshPRs(m.prSource, m.prsQuery(), prCount)
+}
+
+type prUpdate struct {
+	prRes   *prResult
+	changes prChanges
+}
+
+// setRefreshedPRs replaces the PR list with prs, marking the PRs that have
+// changed since the last fetch, and keeps the cursor on the selected PR. It
+// returns the PRs that have changed, along with what's changed about them.
+func (m *Model) setRefreshedPRs(prs []pr) []prUpdate {
+	prev := make(map[string]*prResult, len(m.prCache))
+	for _, prRes := range m.prCache {
+		prev[prRes.identifier] = prRes
+	}
+
+	sectionCache := m.prDetailsCurSectionCache
+	m.setPRs(prs)
+	m.prDetailsCurSectionCache = sectionCache
+
+	var updates []prUpdate
+	for _, prRes := range m.prCache {
+		var prevPR *pr
+		prevRes, ok := prev[prRes.identifier]
+		if ok {
+			prevPR = prevRes.pr
+			prRes.changes = prevRes.changes
+		}
+
+		changes := getPRChanges(prevPR, prRes.pr)
+		if changes != 0 {
+			prRes.changes |= changes
+			updates = append(updates, prUpdate{prRes, changes})
+		}
+	}
+
+	return updates
+}
@@ -0,0 +1,159 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func prWithChecksState(number int, updatedAt time.Time, state string) pr {
+	p := pr{Number: number, UpdatedAt: updatedAt}
+	p.LastCommit.Nodes = make([]struct {
+		Commit struct {
+			StatusCheckRollup *struct {
+				State string
+			}
+		}
+	}, 1)
+	p.LastCommit.Nodes[0].Commit.StatusCheckRollup = &struct {
+		State string
+	}{state}
+
+	return p
+}
+
+func TestGetPRChanges(t *testing.T) {
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	after := before.Add(time.Hour)
+
+	prev := prWithChecksState(1, before, checksStatePending)
+	prev.Commits.TotalCount = 1
+
+	withCommits := prWithChecksState(1, after, checksStatePending)
+	withCommits.Commits.TotalCount = 2
+
+	withReviews := prWithChecksState(1, after, checksStatePending)
+	withReviews.Commits.TotalCount = 1
+	withReviews.Reviews.TotalCount = 1
+
+	checksFinished := prWithChecksState(1, before, statusStateFailure)
+	checksFinished.Commits.TotalCount = 1
+
+	testCases := []struct {
+		name     string
+		prev     *pr
+		cur      pr
+		expected prChanges
+	}{
+		{"new PR", nil, prev, prChangeNew},
+		{"unchanged", &prev, prev, 0},
+		{"new commits", &prev, withCommits, prChangeCommits},
+		{"new reviews", &prev, withReviews, prChangeReviews},
+		{"checks failed without an update", &prev, checksFinished, prChangeChecks | prChangeChecksFailed},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, getPRChanges(tt.prev, &tt.cur))
+		})
+	}
+}
+
+func TestRefreshMarksChangedPRsAndKeepsSelection(t *testing.T) {
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := "type:pr author:@me"
+	m := InitialModel(&fakePRSource{}, Config{Query: &query}, QueryMode)
+	m.awaitingPRs = false
+	m.setPRs([]pr{
+		prWithChecksState(1, before, checksStatePending),
+		prWithChecksState(2, before, checksStatePending),
+	})
+	m.prsList.Select(1)
+
+	changed := m.setRefreshedPRs([]pr{
+		prWithChecksState(3, before, checksStatePending),
+		prWithChecksState(1, before, checksStatePending),
+		prWithChecksState(2, before, statusStateSuccess),
+	})
+
+	require.Len(t, changed, 2)
+	assert.Equal(t, prChangeNew, m.prCache[0].changes)
+	assert.Equal(t, prChanges(0), m.prCache[1].changes)
+	assert.Equal(t, prChangeChecks, m.prCache[2].changes)
+
+	selected, ok := m.prsList.SelectedItem().(*prResult)
+	require.True(t, ok)
+	assert.Equal(t, 2, selected.pr.Number)
+	assert.Contains(t, selected.Title(), "checks changed")
+
+	// changes stay marked until the PR is looked at
+	m.setRefreshedPRs([]pr{
+		prWithChecksState(3, before, checksStatePending),
+		prWithChecksState(1, before, checksStatePending),
+		prWithChecksState(2, before, statusStateSuccess),
+	})
+	assert.Equal(t, prChangeChecks, m.prCache[2].changes)
+
+	m.setTL()
+	assert.Equal(t, prChanges(0), m.prCache[2].changes)
+}
+
+func TestRefreshIsSkippedWhileFetchingPRs(t *testing.T) {
+	query := "type:pr author:@me"
+	m := InitialModel(&fakePRSource{}, Config{Query: &query, RefreshInterval: time.Minute}, QueryMode)
+
+	assert.Nil(t, m.refreshPRsIfIdle())
+
+	m.awaitingPRs = false
+	assert.NotNil(t, m.refreshPRsIfIdle())
+}
+
+func TestPagesFetchedBeforeAReloadAreDropped(t *testing.T) {
+	query := "type:pr author:@me"
+	src := &fakePRSource{prs: []pr{{Number: 1}, {Number: 2}, {Number: 3}}}
+	m := InitialModel(src, Config{Query: &query, PRCount: 1}, QueryMode)
+
+	updated, _ := m.Update(fetchPRSFromQuery(src, query, 1)())
+	m = updated.(Model)
+	require.Len(t, m.prCache, 1)
+
+	// the cursor being on the last PR has the next page fetched
+	require.True(t, m.fetchingMorePRs)
+	nextPage := fetchMorePRs(src, query, m.prsGeneration, 1, m.prsPageInfo.EndCursor)()
+
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'r', Mod: tea.ModCtrl})
+	m = updated.(Model)
+	assert.True(t, m.awaitingPRs)
+	assert.False(t, m.fetchingMorePRs)
+
+	// the page arrives after the reload was asked for, for the same query
+	updated, _ = m.Update(nextPage)
+	m = updated.(Model)
+	assert.Len(t, m.prCache, 1)
+}
+
+func TestRefreshKeepsPRsBeyondTheFirstPage(t *testing.T) {
+	query := "type:pr author:@me"
+	prs := make([]pr, 150)
+	for i := range prs {
+		prs[i] = pr{Number: i + 1}
+	}
+	src := &fakePRSource{prs: prs}
+	m := InitialModel(src, Config{Query: &query, PRCount: 50}, QueryMode)
+	m.awaitingPRs = false
+	m.setPRs(prs)
+
+	msg, ok := m.refreshPRsIfIdle()().(prsRefreshedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	assert.Len(t, msg.prs, 150)
+	assert.Equal(t, 2, src.numSearches)
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	assert.Len(t, m.prCache, 150)
+	assert.False(t, m.prsPageInfo.HasNextPage)
+}
@@ -13,7 +13,7 @@ const (
 	wideScreenWidthFrac   = 0.9
 )
 
-func getPRTitle(pr *pr) string {
+func getPRTitle(pr *pr, unread bool) string {
 	if pr == nil {
 		return ""
 	}
@@ -30,7 +30,11 @@ func getPRTitle(pr *pr) string {
 			reviewDecision = "🟡 "
 		}
 	}
-	return fmt.Sprintf("%s#%2d %s", reviewDecision, pr.Number, pr.PRTitle)
+	var unreadDot string
+	if unread {
+		unreadDot = unreadDotStyle.Render("● ")
+	}
+	return fmt.Sprintf("%s%s#%2d %s", unreadDot, reviewDecision, pr.Number, pr.PRTitle)
 }
 
 func getPRDesc(pr *pr, mode Mode, terminalDetails terminalDetails) string {
@@ -143,6 +147,62 @@ func getPRTLItemTitle(item *prTLItem) string {
 		author := getDynamicStyle(item.MergedEvent.Actor.Login).Render(item.MergedEvent.Actor.Login)
 		date = dateStyle.Render(humanize.Time(item.MergedEvent.CreatedAt))
 		title = fmt.Sprintf("%smerged the PR%s", author, date)
+
+	case tlItemIssueComment:
+		author := getDynamicStyle(item.IssueComment.Author.Login).Render(item.IssueComment.Author.Login)
+		date = dateStyle.Render(humanize.Time(item.IssueComment.CreatedAt))
+		title = fmt.Sprintf("%scommented%s", author, date)
+
+	case tlItemClosedEvent:
+		actor := getDynamicStyle(item.ClosedEvent.Actor.Login).Render(item.ClosedEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.ClosedEvent.CreatedAt))
+		title = fmt.Sprintf("%sclosed the PR%s", actor, date)
+
+	case tlItemReopenedEvent:
+		actor := getDynamicStyle(item.ReopenedEvent.Actor.Login).Render(item.ReopenedEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.ReopenedEvent.CreatedAt))
+		title = fmt.Sprintf("%sreopened the PR%s", actor, date)
+
+	case tlItemLabeledEvent:
+		actor := getDynamicStyle(item.LabeledEvent.Actor.Login).Render(item.LabeledEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.LabeledEvent.CreatedAt))
+		title = fmt.Sprintf("%sadded the label \"%s\"%s", actor, item.LabeledEvent.Label.Name, date)
+
+	case tlItemUnlabeledEvent:
+		actor := getDynamicStyle(item.UnlabeledEvent.Actor.Login).Render(item.UnlabeledEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.UnlabeledEvent.CreatedAt))
+		title = fmt.Sprintf("%sremoved the label \"%s\"%s", actor, item.UnlabeledEvent.Label.Name, date)
+
+	case tlItemConvertToDraftEvent:
+		actor := getDynamicStyle(item.ConvertToDraftEvent.Actor.Login).Render(item.ConvertToDraftEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.ConvertToDraftEvent.CreatedAt))
+		title = fmt.Sprintf("%sconverted the PR to a draft%s", actor, date)
+
+	case tlItemReviewDismissedEvent:
+		actor := getDynamicStyle(item.ReviewDismissedEvent.Actor.Login).Render(item.ReviewDismissedEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.ReviewDismissedEvent.CreatedAt))
+		if item.ReviewDismissedEvent.Review != nil {
+			reviewer := getDynamicStyle(item.ReviewDismissedEvent.Review.Author.Login).Render(item.ReviewDismissedEvent.Review.Author.Login)
+			title = fmt.Sprintf("%sdismissed a review by %s%s", actor, reviewer, date)
+		} else {
+			title = fmt.Sprintf("%sdismissed a review%s", actor, date)
+		}
+
+	case tlItemAssignedEvent:
+		actor := getDynamicStyle(item.AssignedEvent.Actor.Login).Render(item.AssignedEvent.Actor.Login)
+		assignee := getDynamicStyle(item.AssignedEvent.Assignee.User.Login).Render(item.AssignedEvent.Assignee.User.Login)
+		date = dateStyle.Render(humanize.Time(item.AssignedEvent.CreatedAt))
+		title = fmt.Sprintf("%sassigned %s%s", actor, assignee, date)
+
+	case tlItemRenamedTitleEvent:
+		actor := getDynamicStyle(item.RenamedTitleEvent.Actor.Login).Render(item.RenamedTitleEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.RenamedTitleEvent.CreatedAt))
+		title = fmt.Sprintf("%srenamed the PR%s", actor, date)
+
+	case tlItemBaseRefChangedEvent:
+		actor := getDynamicStyle(item.BaseRefChangedEvent.Actor.Login).Render(item.BaseRefChangedEvent.Actor.Login)
+		date = dateStyle.Render(humanize.Time(item.BaseRefChangedEvent.CreatedAt))
+		title = fmt.Sprintf("%schanged the base branch from %s to %s%s", actor, item.BaseRefChangedEvent.PreviousRefName, item.BaseRefChangedEvent.CurrentRefName, date)
 	}
 	return title
 }
@@ -167,6 +227,30 @@ func getPRTLItemDesc(item *prTLItem) string {
 		desc = fmt.Sprintf("🔎 %s%s", reviewState, comment)
 	case tlItemMergedEvent:
 		desc = fmt.Sprintf("🚀 message: %s", item.MergedEvent.MergeCommit.MessageHeadline)
+	case tlItemIssueComment:
+		desc = fmt.Sprintf("💬 %s", strings.Split(item.IssueComment.Body, "\n")[0])
+	case tlItemClosedEvent:
+		desc = "🚫 closed"
+	case tlItemReopenedEvent:
+		desc = "🔁 reopened"
+	case tlItemLabeledEvent:
+		desc = fmt.Sprintf("🏷️  + %s", item.LabeledEvent.Label.Name)
+	case tlItemUnlabeledEvent:
+		desc = fmt.Sprintf("🏷️  - %s", item.UnlabeledEvent.Label.Name)
+	case tlItemConvertToDraftEvent:
+		desc = "📝 draft"
+	case tlItemReviewDismissedEvent:
+		var message string
+		if item.ReviewDismissedEvent.DismissalMessage != nil {
+			message = fmt.Sprintf(" message: %s", strings.Split(*item.ReviewDismissedEvent.DismissalMessage, "\n")[0])
+		}
+		desc = fmt.Sprintf("🙅%s", message)
+	case tlItemAssignedEvent:
+		desc = fmt.Sprintf("👤 assignee: %s", item.AssignedEvent.Assignee.User.Login)
+	case tlItemRenamedTitleEvent:
+		desc = fmt.Sprintf("✏️  %s → %s", item.RenamedTitleEvent.PreviousTitle, item.RenamedTitleEvent.CurrentTitle)
+	case tlItemBaseRefChangedEvent:
+		desc = fmt.Sprintf("🌿 %s → %s", item.BaseRefChangedEvent.PreviousRefName, item.BaseRefChangedEvent.CurrentRefName)
 	}
 	return desc
 }
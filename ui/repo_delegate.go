@@ -1,11 +1,20 @@
 package ui
 
 import (
+	"fmt"
+	"io"
+
 	"charm.land/bubbles/v2/list"
 	"charm.land/lipgloss/v2"
 )
 
-func newRepoListItemDel() list.DefaultDelegate {
+// repoListItemDel renders repos the same way as list.DefaultDelegate, and
+// additionally renders the (non-selectable) owner group headers.
+type repoListItemDel struct {
+	list.DefaultDelegate
+}
+
+func newRepoListItemDel() repoListItemDel {
 	d := list.NewDefaultDelegate()
 
 	d.Styles.SelectedTitle = d.Styles.
@@ -15,5 +24,73 @@ func newRepoListItemDel() list.DefaultDelegate {
 	d.Styles.SelectedDesc = d.Styles.
 		SelectedTitle
 
-	return d
+	return repoListItemDel{d}
+}
+
+func (d repoListItemDel) Render(w io.Writer, m list.Model, index int, item list.Item) {
+	header, ok := item.(repoGroupHeader)
+	if !ok {
+		d.DefaultDelegate.Render(w, m, index, item)
+		return
+	}
+
+	fmt.Fprintf(w, "%s\n%s",
+		repoGroupHeaderStyle.Render(header.Title()),
+		repoGroupCountStyle.Render(header.Description()),
+	)
+}
+
+// getRepoListItems groups repos by owner (and host), in the order the
+// owners first appear, with a header preceding every group.
+func getRepoListItems(repos []Repo) []list.Item {
+	type groupKey struct {
+		host  string
+		owner string
+	}
+
+	var keys []groupKey
+	groups := make(map[groupKey][]Repo)
+	for _, repo := range repos {
+		k := groupKey{repo.Host, repo.Owner}
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], repo)
+	}
+
+	items := make([]list.Item, 0, len(repos)+len(keys))
+	for _, k := range keys {
+		items = append(items, repoGroupHeader{
+			host:     k.host,
+			owner:    k.owner,
+			numRepos: len(groups[k]),
+		})
+		for _, repo := range groups[k] {
+			items = append(items, repo)
+		}
+	}
+
+	return items
+}
+
+// skipRepoGroupHeader moves the repo list's cursor off a group header, in the
+// direction the cursor was moving in.
+func (m *Model) skipRepoGroupHeader(prevIndex int) {
+	index := m.repoList.Index()
+	if _, ok := m.repoList.SelectedItem().(repoGroupHeader); !ok {
+		return
+	}
+
+	numItems := len(m.repoList.Items())
+	movingUp := index < prevIndex
+	switch {
+	case movingUp && index > 0:
+		m.repoList.Select(index - 1)
+	case !movingUp && index+1 < numItems:
+		m.repoList.Select(index + 1)
+	case index+1 < numItems:
+		m.repoList.Select(index + 1)
+	case index > 0:
+		m.repoList.Select(index - 1)
+	}
 }
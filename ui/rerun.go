@@ -0,0 +1,245 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+const (
+	checksPollInterval = 10 * time.Second
+	// checks that haven't finished after this many polls are left alone
+	checksMaxPolls = 60
+	// Github can take a while to reset re-run checks; until they're seen
+	// running, finished checks are assumed to be the ones from before
+	checksMinPolls = 3
+
+	checkConclusionStateTimedOut       = "TIMED_OUT"
+	checkConclusionStateCancelled      = "CANCELLED"
+	checkConclusionStateStartupFailure = "STARTUP_FAILURE"
+)
+
+var errCouldntRerunWorkflowRun = errors.New("couldn't re-run workflow run")
+
+// failedCheckSuite is a check suite on a PR's latest commit with at least one
+// failed check run. Suites created by Github Actions are re-run via the
+// workflow run they're for, the rest are re-requested from their app.
+type failedCheckSuite struct {
+	id            string
+	workflowRunID *int
+}
+
+// checksPoll keeps track of the checks of a PR being polled after they've
+// been re-run.
+type checksPoll struct {
+	host       string
+	polls      int
+	sawPending bool
+}
+
+func isFailedCheckConclusion(conclusion *string) bool {
+	if conclusion == nil {
+		return false
+	}
+
+	switch *conclusion {
+	case checkConclusionStateFailure,
+		checkConclusionStateError,
+		checkConclusionStateTimedOut,
+		checkConclusionStateCancelled,
+		checkConclusionStateStartupFailure:
+		return true
+	default:
+		return false
+	}
+}
+
+// getFailedCheckSuites returns the check suites on a PR's latest commit that
+// have failed check runs, in the order they first appear in.
+func getFailedCheckSuites(details prDetails) []failedCheckSuite {
+	if len(details.LastCommit.Nodes) == 0 || details.LastCommit.Nodes[0].Commit.StatusCheckRollup == nil {
+		return nil
+	}
+
+	var suites []failedCheckSuite
+	seen := make(map[string]bool)
+	for _, n := range details.LastCommit.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes {
+		if n.Type != checkRunType || !isFailedCheckConclusion(n.CheckRun.Conclusion) {
+			continue
+		}
+
+		suite := n.CheckRun.CheckSuite
+		if suite.ID == "" || seen[suite.ID] {
+			continue
+		}
+		seen[suite.ID] = true
+
+		failed := failedCheckSuite{id: suite.ID}
+		if suite.WorkflowRun != nil {
+			runID := suite.WorkflowRun.DatabaseID
+			failed.workflowRunID = &runID
+		}
+		suites = append(suites, failed)
+	}
+
+	return suites
+}
+
+// areChecksPending reports whether any of the checks on a PR's latest commit
+// are yet to finish.
+func areChecksPending(details prDetails) bool {
+	if len(details.LastCommit.Nodes) == 0 || details.LastCommit.Nodes[0].Commit.StatusCheckRollup == nil {
+		return false
+	}
+
+	rollup := details.LastCommit.Nodes[0].Commit.StatusCheckRollup
+	switch rollup.State {
+	case checksStatePending, checksStateExpected:
+		return true
+	}
+
+	for _, n := range rollup.Contexts.Nodes {
+		if n.Type == checkRunType && n.CheckRun.Status != checkStatusStateCompleted {
+			return true
+		}
+	}
+	return false
+}
+
+// startChecksRerun asks for a confirmation to re-run the failed check suites
+// on the selected PR's latest commit.
+func (m *Model) startChecksRerun() {
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return
+	}
+
+	details, ok := m.prDetailsCache[prRes.identifier]
+	if !ok {
+		m.message = "PR details were not retrieved yet"
+		return
+	}
+
+	if _, ok := m.checksPolls[prRes.identifier]; ok {
+		m.message = "checks are being re-run already"
+		return
+	}
+
+	suites := getFailedCheckSuites(details)
+	if len(suites) == 0 {
+		m.message = "there are no failed checks to re-run"
+		return
+	}
+
+	m.askForConfirmation(
+		fmt.Sprintf("Re-run %d failed check suite(s) on #%d?", len(suites), prRes.pr.Number),
+		"re-running checks...",
+		rerunChecksCmd(m.prSourceForHost(getPRHost(prRes.pr)), prRes, details.Repository.ID, suites),
+	)
+}
+
+func rerunChecksCmd(prSource prDataSource, prRes *prResult, repoID string, suites []failedCheckSuite) tea.Cmd {
+	repoOwner := prRes.pr.Repository.Owner.Login
+	repoName := prRes.pr.Repository.Name
+	host := getPRHost(prRes.pr)
+	msg := checksRerunMsg{
+		identifier: prRes.identifier,
+		host:       host,
+		repoOwner:  repoOwner,
+		repoName:   repoName,
+		prNumber:   prRes.pr.Number,
+	}
+
+	return func() tea.Msg {
+		for _, suite := range suites {
+			// the suites that were re-run before a failure are still polled
+			err := rerunCheckSuite(prSource, repoOwner, repoName, repoID, suite)
+			if err != nil {
+				msg.err = err
+				return msg
+			}
+			msg.numRerun++
+		}
+
+		return msg
+	}
+}
+
+func rerunCheckSuite(prSource prDataSource, repoOwner, repoName, repoID string, suite failedCheckSuite) error {
+	if suite.workflowRunID != nil {
+		rerunner, ok := prSource.(workflowRunRerunner)
+		if !ok {
+			return errActionNotSupported
+		}
+		return rerunner.RerunFailedJobs(repoOwner, repoName, *suite.workflowRunID)
+	}
+
+	rerequester, ok := prSource.(checkSuiteRerequester)
+	if !ok {
+		return errActionNotSupported
+	}
+	return rerequester.RerequestCheckSuite(repoID, suite.id)
+}
+
+// rerunFailedJobs re-runs the failed jobs (and the ones that depend on them)
+// in a Github Actions workflow run.
+func rerunFailedJobs(client restRequester, repoOwner, repoName string, workflowRunID int) error {
+	path := fmt.Sprintf("repos/%s/%s/actions/runs/%d/rerun-failed-jobs", url.PathEscape(repoOwner), url.PathEscape(repoName), workflowRunID)
+	resp, err := client.Request(http.MethodPost, path, nil)
+	if err != nil {
+		return fmt.Errorf("%w: %s", errCouldntRerunWorkflowRun, err.Error())
+	}
+	resp.Body.Close()
+
+	return nil
+}
+
+func pollChecks(identifier, repoOwner, repoName string, prNumber int) tea.Cmd {
+	return tea.Tick(checksPollInterval, func(time.Time) tea.Msg {
+		return checksPollMsg{identifier, repoOwner, repoName, prNumber}
+	})
+}
+
+func fetchPolledChecks(prSource prDataSource, identifier, repoOwner, repoName string, prNumber int) tea.Cmd {
+	return func() tea.Msg {
+		details, err := prSource.GetPRDetails(repoOwner, repoName, prNumber)
+		return checksPolledMsg{identifier, prNumber, details, err}
+	}
+}
+
+// trackPolledChecks is called with the freshly fetched details of a PR whose
+// checks are being polled, and returns a command to poll them again, unless
+// they've finished.
+func (m *Model) trackPolledChecks(identifier string, details prDetails) tea.Cmd {
+	poll, ok := m.checksPolls[identifier]
+	if !ok {
+		return nil
+	}
+
+	poll.polls++
+	pending := areChecksPending(details)
+	if pending {
+		poll.sawPending = true
+	}
+
+	switch {
+	case pending && poll.polls < checksMaxPolls,
+		!pending && !poll.sawPending && poll.polls < checksMinPolls:
+		return pollChecks(identifier, details.Repository.Owner.Login, details.Repository.Name, details.Number)
+	case pending:
+		m.message = fmt.Sprintf("stopped following checks on #%d; they're still running", details.Number)
+	default:
+		var state string
+		if len(details.LastCommit.Nodes) > 0 && details.LastCommit.Nodes[0].Commit.StatusCheckRollup != nil {
+			state = details.LastCommit.Nodes[0].Commit.StatusCheckRollup.State
+		}
+		m.message = fmt.Sprintf("checks on #%d finished: %s", details.Number, state)
+	}
+
+	delete(m.checksPolls, identifier)
+	return nil
+}
@@ -0,0 +1,149 @@
+package ui
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeRerequestingSource struct {
+	fakePRSource
+	rerequested  []string
+	workflowRuns []int
+}
+
+func (s *fakeRerequestingSource) RerequestCheckSuite(repoID, checkSuiteID string) error {
+	s.rerequested = append(s.rerequested, repoID+"/"+checkSuiteID)
+	return nil
+}
+
+func (s *fakeRerequestingSource) RerunFailedJobs(_, _ string, workflowRunID int) error {
+	s.workflowRuns = append(s.workflowRuns, workflowRunID)
+	return nil
+}
+
+func TestGetFailedCheckSuites(t *testing.T) {
+	details := detailsWithChecks()
+	nodes := details.LastCommit.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes
+
+	// another failed run in the same suite
+	timedOut := checkConclusionStateTimedOut
+	nodes = append(nodes, nodes[0])
+	nodes[3].CheckRun.Conclusion = &timedOut
+
+	// a failed run in a workflow run
+	nodes = append(nodes, nodes[0])
+	nodes[4].CheckRun.CheckSuite.ID = "CS_3"
+	nodes[4].CheckRun.CheckSuite.WorkflowRun = &struct{ DatabaseID int }{DatabaseID: 42}
+	details.LastCommit.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes = nodes
+
+	got := getFailedCheckSuites(details)
+
+	require.Len(t, got, 2)
+	assert.Equal(t, "CS_1", got[0].id)
+	assert.Nil(t, got[0].workflowRunID)
+	assert.Equal(t, "CS_3", got[1].id)
+	require.NotNil(t, got[1].workflowRunID)
+	assert.Equal(t, 42, *got[1].workflowRunID)
+}
+
+func TestRerunFailedJobs(t *testing.T) {
+	client := &fakeRESTClient{}
+
+	err := rerunFailedJobs(client, "dhth", "prs", 42)
+	require.NoError(t, err)
+	assert.Equal(t, "repos/dhth/prs/actions/runs/42/rerun-failed-jobs", client.path)
+
+	client = &fakeRESTClient{err: io.ErrUnexpectedEOF}
+	err = rerunFailedJobs(client, "dhth", "prs", 42)
+	assert.ErrorIs(t, err, errCouldntRerunWorkflowRun)
+}
+
+func TestWorkflowRunsAreRerunViaTheSource(t *testing.T) {
+	src := &fakeRerequestingSource{}
+	runID := 42
+
+	err := rerunCheckSuite(src, "dhth", "prs", "R_1", failedCheckSuite{id: "CS_3", workflowRunID: &runID})
+	require.NoError(t, err)
+
+	assert.Equal(t, []int{42}, src.workflowRuns)
+	assert.Empty(t, src.rerequested)
+}
+
+func TestRerunningChecks(t *testing.T) {
+	src := &fakeRerequestingSource{}
+	m := newTestModel(t, src, pr{Number: 1})
+
+	identifier := m.prCache[0].identifier
+	m.prDetailsCache[identifier] = detailsWithChecks()
+	m.activePane = prDetailsView
+	m.prDetailsCurrentSection = uint(PRChecks)
+
+	updated, _ := m.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	m = updated.(Model)
+	require.NotNil(t, m.confirmation)
+	assert.Contains(t, m.confirmation.prompt, "Re-run 1 failed check suite(s) on #1?")
+
+	updated, cmd := m.Update(tea.KeyPressMsg{Code: 'y', Text: "y"})
+	m = updated.(Model)
+	require.NotNil(t, cmd)
+
+	msg, ok := cmd().(checksRerunMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+	assert.Equal(t, []string{"R_1/CS_1"}, src.rerequested)
+
+	updated, cmd = m.Update(msg)
+	m = updated.(Model)
+	assert.NotNil(t, cmd)
+	require.Contains(t, m.checksPolls, identifier)
+	assert.Contains(t, m.prDetailsVP.GetContent(), "re-running failed checks")
+
+	// checks can't be re-run while they're being followed
+	updated, _ = m.Update(tea.KeyPressMsg{Code: 'R', Text: "R"})
+	m = updated.(Model)
+	assert.Nil(t, m.confirmation)
+
+	fetched := func(details prDetails) tea.Cmd {
+		t.Helper()
+		updated, cmd := m.Update(checksPolledMsg{identifier, 1, details, nil})
+		m = updated.(Model)
+		return cmd
+	}
+
+	// the old results are still around until Github resets the checks
+	old := detailsWithChecks()
+	old.LastCommit.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes[2].CheckRun.Status = checkStatusStateCompleted
+	assert.NotNil(t, fetched(old))
+	assert.False(t, m.checksPolls[identifier].sawPending)
+
+	pending := detailsWithChecks()
+	pending.LastCommit.Nodes[0].Commit.StatusCheckRollup.State = checksStatePending
+	assert.NotNil(t, fetched(pending))
+	assert.True(t, m.checksPolls[identifier].sawPending)
+
+	// failing to fetch the PR's details for something else doesn't stop
+	// following its checks
+	updated, _ = m.Update(prMetadataFetchedMsg{identifier, prDetails{}, errors.New("timed out")})
+	m = updated.(Model)
+	assert.Contains(t, m.checksPolls, identifier)
+
+	done := detailsWithChecks()
+	done.LastCommit.Nodes[0].Commit.StatusCheckRollup.State = statusStateSuccess
+	done.LastCommit.Nodes[0].Commit.StatusCheckRollup.Contexts.Nodes[2].CheckRun.Status = checkStatusStateCompleted
+	fetched(done)
+	assert.NotContains(t, m.checksPolls, identifier)
+	assert.Equal(t, "checks on #1 finished: SUCCESS", m.message)
+	assert.NotContains(t, m.prDetailsVP.GetContent(), "re-running failed checks")
+
+	// failing to poll them does, though
+	m.checksPolls[identifier] = &checksPoll{}
+	updated, _ = m.Update(checksPolledMsg{identifier, 1, prDetails{}, errors.New("timed out")})
+	m = updated.(Model)
+	assert.NotContains(t, m.checksPolls, identifier)
+	assert.Equal(t, "stopped following checks on #1: timed out", m.message)
+}
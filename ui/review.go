@@ -0,0 +1,61 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+var errActionNotSupported = errors.New("action not supported for this source")
+
+// startReview opens the compose view for a review on the selected PR.
+func (m *Model) startReview(event PullRequestReviewEvent) tea.Cmd {
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	if prRes.pr.ID == "" {
+		m.message = "PR's ID is not available; reload the PR list with ctrl+r"
+		return nil
+	}
+
+	prSource := m.prSourceForHost(getPRHost(prRes.pr))
+	c := composer{
+		title: fmt.Sprintf("%s: #%d %s", event.label(), prRes.pr.Number, prRes.pr.PRTitle),
+		onSubmit: func(body string) (string, string, tea.Cmd) {
+			return fmt.Sprintf("%s PR #%d?", event.label(), prRes.pr.Number),
+				"submitting review...",
+				submitReview(prSource, prRes, event, body)
+		},
+	}
+	if event.bodyRequired() {
+		c.emptyBodyMsg = fmt.Sprintf("A body is required to %s", strings.ToLower(event.label()))
+	}
+
+	return m.startComposing(c, "Review body (optional when approving)")
+}
+
+func submitReview(prSource prDataSource, prRes *prResult, event PullRequestReviewEvent, body string) tea.Cmd {
+	p := prRes.pr
+	return func() tea.Msg {
+		msg := reviewSubmittedMsg{
+			identifier: prRes.identifier,
+			host:       getPRHost(p),
+			repoOwner:  p.Repository.Owner.Login,
+			repoName:   p.Repository.Name,
+			prNumber:   p.Number,
+		}
+
+		reviewer, ok := prSource.(prReviewer)
+		if !ok {
+			msg.err = errActionNotSupported
+			return msg
+		}
+
+		msg.state, msg.err = reviewer.SubmitPRReview(p.ID, event, body)
+		return msg
+	}
+}
@@ -0,0 +1,45 @@
+package ui
+
+import (
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReviewRequiresBodyForRequestingChanges(t *testing.T) {
+	m := newTestModel(t, &fakePRSource{}, pr{ID: "PR_1", Number: 1, PRTitle: "one"})
+	m.startReview(reviewRequestChanges)
+	require.Equal(t, composeView, m.activePane)
+
+	m, _ = m.updateComposeView(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+
+	assert.Nil(t, m.confirmation)
+	assert.Contains(t, m.message, "body is required")
+}
+
+func TestReviewAsksForConfirmationBeforeSubmitting(t *testing.T) {
+	m := newTestModel(t, &fakePRSource{}, pr{ID: "PR_1", Number: 1, PRTitle: "one"})
+	m.startReview(reviewApprove)
+
+	m, _ = m.updateComposeView(tea.KeyPressMsg{Code: 's', Mod: tea.ModCtrl})
+	require.NotNil(t, m.confirmation)
+	assert.Equal(t, "Approve PR #1?", m.confirmation.prompt)
+
+	cmd := m.handleConfirmation(tea.KeyPressMsg{Code: 'n', Text: "n"})
+	assert.Nil(t, cmd)
+	assert.Nil(t, m.confirmation)
+}
+
+func TestSubmitReviewFailsForSourcesThatCantReview(t *testing.T) {
+	m := newTestModel(t, &fakePRSource{}, pr{ID: "PR_1", Number: 1, PRTitle: "one"})
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	require.True(t, ok)
+
+	msg := submitReview(&fakePRSource{}, prRes, reviewApprove, "")()
+
+	submitted, ok := msg.(reviewSubmittedMsg)
+	require.True(t, ok)
+	assert.ErrorIs(t, submitted.err, errActionNotSupported)
+}
@@ -0,0 +1,164 @@
+package ui
+
+import (
+	"errors"
+	"io/fs"
+	"maps"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+)
+
+// bump this whenever the shape of saved state (or the PR identifiers it's
+// keyed by) changes
+const seenStateVersion = 1
+
+// seenState records when each PR was last opened, so that PRs with activity
+// since then can be shown as unread. It's persisted to path, unless path is
+// empty.
+type seenState struct {
+	path   string
+	seenAt map[string]time.Time
+	// only one write is in flight at a time, so that an older snapshot can't
+	// overwrite a newer one; changes made during a write are saved after it
+	saving  bool
+	unsaved bool
+}
+
+type seenStateFile struct {
+	Version int                  `json:"version"`
+	SeenAt  map[string]time.Time `json:"seen_at"`
+}
+
+func loadSeenState(path string) (*seenState, error) {
+	s := &seenState{path: path, seenAt: make(map[string]time.Time)}
+	if path == "" {
+		return s, nil
+	}
+
+	var f seenStateFile
+	err := readCacheFile(path, &f)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+
+	if f.Version == seenStateVersion && f.SeenAt != nil {
+		s.seenAt = f.SeenAt
+	}
+	return s, nil
+}
+
+// markSeen records the PRs in seenAt (keyed by identifier) as seen at the
+// times they map to, and returns a command that persists the state.
+func (s *seenState) markSeen(seenAt map[string]time.Time) tea.Cmd {
+	maps.Copy(s.seenAt, seenAt)
+	return s.save()
+}
+
+// save returns a command that writes the state to disk, unless a write is in
+// flight, in which case it's saved once that's done.
+func (s *seenState) save() tea.Cmd {
+	if s.path == "" {
+		return nil
+	}
+
+	if s.saving {
+		s.unsaved = true
+		return nil
+	}
+
+	s.saving = true
+	s.unsaved = false
+	path := s.path
+	snapshot := maps.Clone(s.seenAt)
+	return func() tea.Msg {
+		err := writeCacheFile(path, seenStateFile{Version: seenStateVersion, SeenAt: snapshot})
+		return seenStateSavedMsg{err}
+	}
+}
+
+// saved is called once a write has finished, and returns a command to write
+// the changes made in the meantime, if there are any.
+func (s *seenState) saved() tea.Cmd {
+	s.saving = false
+	if !s.unsaved {
+		return nil
+	}
+	return s.save()
+}
+
+// isUnread reports whether the PR has been updated, or has timeline items
+// newer than when it was last opened.
+func (s *seenState) isUnread(prRes *prResult, tlItems []*prTLItemResult) bool {
+	seenAt, ok := s.seenAt[prRes.identifier]
+	if !ok {
+		return true
+	}
+
+	if prRes.pr.UpdatedAt.After(seenAt) {
+		return true
+	}
+
+	for _, item := range tlItems {
+		if item.item.createdAt().After(seenAt) {
+			return true
+		}
+	}
+	return false
+}
+
+// updateUnread recomputes whether the PR is unread, and its title
+// accordingly.
+func (m *Model) updateUnread(prRes *prResult) {
+	prRes.unread = m.seen.isUnread(prRes, m.prTLCache[prRes.identifier])
+	prRes.title = getPRTitle(prRes.pr, prRes.unread)
+}
+
+// updateUnreadForPR does what updateUnread does for the PR with identifier,
+// if it's in the PR list.
+func (m *Model) updateUnreadForPR(identifier string) {
+	for _, prRes := range m.prCache {
+		if prRes.identifier != identifier {
+			continue
+		}
+
+		m.updateUnread(prRes)
+		return
+	}
+}
+
+// getSeenAt returns the time a PR is marked as seen at: the latest of when it
+// was updated, and when the items in its timeline were created. Github's
+// timestamps are used rather than the local clock, which can be off.
+func (m *Model) getSeenAt(prRes *prResult) time.Time {
+	seenAt := prRes.pr.UpdatedAt
+	for _, item := range m.prTLCache[prRes.identifier] {
+		if t := item.item.createdAt(); t.After(seenAt) {
+			seenAt = t
+		}
+	}
+	return seenAt
+}
+
+func (m *Model) markPRSeen(prRes *prResult) tea.Cmd {
+	cmd := m.seen.markSeen(map[string]time.Time{prRes.identifier: m.getSeenAt(prRes)})
+	m.updateUnread(prRes)
+	return cmd
+}
+
+func (m *Model) markAllPRsSeen() tea.Cmd {
+	seenAt := make(map[string]time.Time, len(m.prCache))
+	for _, prRes := range m.prCache {
+		seenAt[prRes.identifier] = m.getSeenAt(prRes)
+	}
+
+	cmd := m.seen.markSeen(seenAt)
+	for _, prRes := range m.prCache {
+		m.updateUnread(prRes)
+	}
+
+	return cmd
+}
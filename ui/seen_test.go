@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSeenStateIsPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	seenAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := loadSeenState(path)
+	require.NoError(t, err)
+	assert.Empty(t, s.seenAt)
+
+	cmd := s.markSeen(map[string]time.Time{"dhth/prs:1": seenAt})
+	require.NotNil(t, cmd)
+	msg, ok := cmd().(seenStateSavedMsg)
+	require.True(t, ok)
+	require.NoError(t, msg.err)
+
+	got, err := loadSeenState(path)
+	require.NoError(t, err)
+	assert.True(t, seenAt.Equal(got.seenAt["dhth/prs:1"]))
+}
+
+func TestSeenStateWritesOneSnapshotAtATime(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	seenAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	s, err := loadSeenState(path)
+	require.NoError(t, err)
+
+	first := s.markSeen(map[string]time.Time{"dhth/prs:1": seenAt})
+	require.NotNil(t, first)
+	// changes made while a write is in flight wait for it to finish
+	assert.Nil(t, s.markSeen(map[string]time.Time{"dhth/prs:2": seenAt}))
+	assert.Nil(t, s.markSeen(map[string]time.Time{"dhth/prs:3": seenAt}))
+
+	first()
+	second := s.saved()
+	require.NotNil(t, second)
+	second()
+	assert.Nil(t, s.saved())
+
+	got, err := loadSeenState(path)
+	require.NoError(t, err)
+	assert.Len(t, got.seenAt, 3)
+}
+
+func TestIsUnread(t *testing.T) {
+	seenAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	s, err := loadSeenState("")
+	require.NoError(t, err)
+	s.markSeen(map[string]time.Time{"dhth/prs:1": seenAt})
+
+	var newerItem prTLItem
+	newerItem.Type = tlItemPRReview
+	newerItem.PullRequestReview.CreatedAt = seenAt.Add(time.Minute)
+
+	testCases := []struct {
+		name       string
+		identifier string
+		updatedAt  time.Time
+		tlItems    []*prTLItemResult
+		expected   bool
+	}{
+		{"never opened", "dhth/prs:2", seenAt.Add(-time.Hour), nil, true},
+		{"no activity since opened", "dhth/prs:1", seenAt.Add(-time.Hour), nil, false},
+		{"updated since opened", "dhth/prs:1", seenAt.Add(time.Hour), nil, true},
+		{"timeline item since opened", "dhth/prs:1", seenAt.Add(-time.Hour), []*prTLItemResult{{item: &newerItem}}, true},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			prRes := &prResult{pr: &pr{UpdatedAt: tt.updatedAt}, identifier: tt.identifier}
+			assert.Equal(t, tt.expected, s.isUnread(prRes, tt.tlItems))
+		})
+	}
+}
+
+func TestMarkAllPRsSeen(t *testing.T) {
+	query := "type:pr author:@me"
+	updatedAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := InitialModel(&fakePRSource{}, Config{Query: &query}, QueryMode)
+	m.setPRs([]pr{{Number: 1, PRTitle: "one", UpdatedAt: updatedAt}, {Number: 2, PRTitle: "two", UpdatedAt: updatedAt}})
+	require.True(t, m.prCache[0].unread)
+	assert.Contains(t, m.prCache[0].Title(), "●")
+
+	m.markAllPRsSeen()
+
+	for _, prRes := range m.prCache {
+		assert.False(t, prRes.unread)
+		assert.NotContains(t, prRes.Title(), "●")
+		// Github's timestamps are used, rather than the local clock
+		assert.True(t, updatedAt.Equal(m.seen.seenAt[prRes.identifier]))
+	}
+}
@@ -0,0 +1,189 @@
+package ui
+
+import (
+	"cmp"
+	"slices"
+
+	"charm.land/bubbles/v2/list"
+)
+
+// prSortKey is the order PRs are shown in, in the PR list.
+type prSortKey uint
+
+const (
+	sortByQuery prSortKey = iota
+	sortByUpdated
+	sortByCreated
+	sortBySize
+	sortByReviewDecision
+	sortByChecks
+	prSortKeysCount
+)
+
+func (k prSortKey) label() string {
+	switch k {
+	case sortByUpdated:
+		return "updated"
+	case sortByCreated:
+		return "created"
+	case sortBySize:
+		return "size"
+	case sortByReviewDecision:
+		return "review decision"
+	case sortByChecks:
+		return "checks"
+	default:
+		return "query order"
+	}
+}
+
+func (k prSortKey) next() prSortKey {
+	return (k + 1) % prSortKeysCount
+}
+
+// sortPRResults returns prs in the order key asks for; PRs that compare
+// equal stay in the order they were fetched in.
+//
+// Recently updated (or created) PRs come first, as do smaller PRs; PRs
+// that need attention (changes requested, failing checks) come before the
+// ones that don't.
+func sortPRResults(prs []*prResult, key prSortKey) []*prResult {
+	sorted := slices.Clone(prs)
+	switch key {
+	case sortByUpdated:
+		slices.SortStableFunc(sorted, func(a, b *prResult) int {
+			return b.pr.UpdatedAt.Compare(a.pr.UpdatedAt)
+		})
+	case sortByCreated:
+		slices.SortStableFunc(sorted, func(a, b *prResult) int {
+			return b.pr.CreatedAt.Compare(a.pr.CreatedAt)
+		})
+	case sortBySize:
+		slices.SortStableFunc(sorted, func(a, b *prResult) int {
+			return cmp.Compare(a.pr.Additions+a.pr.Deletions, b.pr.Additions+b.pr.Deletions)
+		})
+	case sortByReviewDecision:
+		slices.SortStableFunc(sorted, func(a, b *prResult) int {
+			return cmp.Compare(reviewDecisionRank(a.pr), reviewDecisionRank(b.pr))
+		})
+	case sortByChecks:
+		slices.SortStableFunc(sorted, func(a, b *prResult) int {
+			return cmp.Compare(checksStateRank(a.pr), checksStateRank(b.pr))
+		})
+	}
+	return sorted
+}
+
+func reviewDecisionRank(p *pr) int {
+	if p.ReviewDecision == nil {
+		return 3
+	}
+	switch *p.ReviewDecision {
+	case prRevDecChangesReq:
+		return 0
+	case prRevDecRevReq:
+		return 1
+	case prRevDecApproved:
+		return 2
+	default:
+		return 3
+	}
+}
+
+func checksStateRank(p *pr) int {
+	switch p.checksState() {
+	case statusStateFailure, statusStateError:
+		return 0
+	case checksStatePending, checksStateExpected:
+		return 1
+	case statusStateSuccess:
+		return 2
+	default:
+		return 3
+	}
+}
+
+// filterPRs fuzzy matches PRs the way the list's default filter does, but
+// leaves out the positions of matched characters, as those point into
+// FilterValue, and not into the (styled) title the delegate highlights them
+// in.
+func filterPRs(term string, targets []string) []list.Rank {
+	ranks := list.DefaultFilter(term, targets)
+	for i := range ranks {
+		ranks[i].MatchedIndexes = nil
+	}
+	return ranks
+}
+
+// showPRs replaces the items in l, keeping the cursor on the PR (or group
+// header) it was on, and re-applying a filter, if one is set.
+func showPRs(l *list.Model, items []list.Item) {
+	selected := getPRListItemKey(l.SelectedItem())
+
+	// filtering happens asynchronously after SetItems; the list would be
+	// empty until then, so the filter is applied right away instead
+	filterState := l.FilterState()
+	l.SetItems(items)
+	if filterState != list.Unfiltered {
+		l.SetFilterText(l.FilterValue())
+		if filterState == list.Filtering {
+			l.SetFilterState(list.Filtering)
+		}
+	}
+
+	selectPR(l, selected)
+}
+
+// getPRListItemKey returns what identifies item in the PR list across
+// updates to it.
+func getPRListItemKey(item list.Item) string {
+	switch item := item.(type) {
+	case *prResult:
+		return item.identifier
+	case prGroupHeader:
+		return "group:" + item.name
+	default:
+		return ""
+	}
+}
+
+// selectPR moves the cursor in l to the PR (or group header) with key, if
+// it's visible, and reports whether it is.
+func selectPR(l *list.Model, key string) bool {
+	if key == "" {
+		return false
+	}
+
+	for i, item := range l.VisibleItems() {
+		if getPRListItemKey(item) == key {
+			l.Select(i)
+			return true
+		}
+	}
+	return false
+}
+
+// setPRListItems shows the PRs in m.prCache in the PR list.
+func (m *Model) setPRListItems() {
+	showPRs(&m.prsList, m.getPRListItems(m.prCache))
+}
+
+// cyclePRSort switches the PR list over to the next sort order.
+func (m *Model) cyclePRSort() {
+	m.prSort = m.prSort.next()
+	m.setPRListItems()
+
+	// the title is left alone while it shows what's being fetched
+	if !m.awaitingPRs && !m.fetchingMorePRs && !m.prsFromCache {
+		m.resetPRsListTitle()
+	}
+	m.message = "sorted by " + m.prSort.label()
+}
+
+// clearPRFilter removes the filter applied to the PR list, keeping the cursor
+// on the PR it was on.
+func (m *Model) clearPRFilter() {
+	selected := getPRListItemKey(m.prsList.SelectedItem())
+	m.prsList.ResetFilter()
+	selectPR(&m.prsList, selected)
+}
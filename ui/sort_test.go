@@ -0,0 +1,120 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	"charm.land/bubbles/v2/list"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getPRNumbers(items []list.Item) []int {
+	numbers := make([]int, len(items))
+	for i, item := range items {
+		numbers[i] = item.(*prResult).pr.Number
+	}
+	return numbers
+}
+
+func TestSortPRResults(t *testing.T) {
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	approved := prRevDecApproved
+	changesRequested := prRevDecChangesReq
+
+	first := prWithChecksState(1, before, statusStateSuccess)
+	first.CreatedAt = before.Add(2 * time.Hour)
+	first.Additions = 100
+	first.ReviewDecision = &approved
+
+	second := prWithChecksState(2, before.Add(time.Hour), statusStateFailure)
+	second.CreatedAt = before
+	second.Additions = 5
+	second.Deletions = 5
+
+	third := prWithChecksState(3, before.Add(2*time.Hour), checksStatePending)
+	third.CreatedAt = before.Add(time.Hour)
+	third.Deletions = 50
+	third.ReviewDecision = &changesRequested
+
+	prs := []*prResult{{pr: &first}, {pr: &second}, {pr: &third}}
+
+	testCases := []struct {
+		key      prSortKey
+		expected []int
+	}{
+		{sortByQuery, []int{1, 2, 3}},
+		{sortByUpdated, []int{3, 2, 1}},
+		{sortByCreated, []int{1, 3, 2}},
+		{sortBySize, []int{2, 3, 1}},
+		{sortByReviewDecision, []int{3, 1, 2}},
+		{sortByChecks, []int{2, 3, 1}},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.key.label(), func(t *testing.T) {
+			sorted := sortPRResults(prs, tt.key)
+			numbers := make([]int, len(sorted))
+			for i, prRes := range sorted {
+				numbers[i] = prRes.pr.Number
+			}
+			assert.Equal(t, tt.expected, numbers)
+		})
+	}
+
+	// prs itself is left in the order it was fetched in
+	assert.Equal(t, 1, prs[0].pr.Number)
+}
+
+func TestPRFilterValue(t *testing.T) {
+	p := pr{Number: 42, PRTitle: "add caching"}
+	p.Author.Login = "octocat"
+	p.Repository.Owner.Login = "dhth"
+	p.Repository.Name = "prs"
+	p.Labels.Nodes = []prLabel{{ID: "L1", Name: "bug"}}
+
+	assert.Equal(t, "#42 add caching octocat dhth/prs bug", prResult{pr: &p}.FilterValue())
+}
+
+func TestSortingAndFilteringKeepSelection(t *testing.T) {
+	before := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	query := "type:pr author:@me"
+	m := InitialModel(&fakePRSource{}, Config{Query: &query}, QueryMode)
+	m.awaitingPRs = false
+
+	prs := make([]pr, 3)
+	for i := range prs {
+		prs[i] = prWithChecksState(i+1, before.Add(time.Duration(i)*time.Hour), statusStateSuccess)
+		prs[i].PRTitle = "fix"
+	}
+	prs[1].PRTitle = "feature"
+	m.setPRs(prs)
+	m.prsList.Select(2)
+
+	m.cyclePRSort()
+	assert.Equal(t, sortByUpdated, m.prSort)
+	assert.Equal(t, []int{3, 2, 1}, getPRNumbers(m.prsList.VisibleItems()))
+	assert.Contains(t, m.prsList.Title, "(by updated)")
+
+	selected, ok := m.prsList.SelectedItem().(*prResult)
+	require.True(t, ok)
+	assert.Equal(t, 3, selected.pr.Number)
+
+	m.prsList.SetFilterText("fix")
+	assert.Equal(t, []int{3, 1}, getPRNumbers(m.prsList.VisibleItems()))
+
+	// a refresh keeps the filter, and the selected PR
+	m.prsList.Select(1)
+	m.setRefreshedPRs(prs)
+	assert.Equal(t, list.FilterApplied, m.prsList.FilterState())
+	assert.Equal(t, []int{3, 1}, getPRNumbers(m.prsList.VisibleItems()))
+	selected, ok = m.prsList.SelectedItem().(*prResult)
+	require.True(t, ok)
+	assert.Equal(t, 1, selected.pr.Number)
+
+	m.clearPRFilter()
+	assert.Equal(t, []int{3, 2, 1}, getPRNumbers(m.prsList.VisibleItems()))
+	selected, ok = m.prsList.SelectedItem().(*prResult)
+	require.True(t, ok)
+	assert.Equal(t, 1, selected.pr.Number)
+}
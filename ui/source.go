@@ -0,0 +1,206 @@
+package ui
+
+import (
+	ghapi "github.com/cli/go-gh/v2/pkg/api"
+)
+
+// prDataSource is the backend prs fetches pull request data from; backends
+// for other forges go alongside GHSource.
+type prDataSource interface {
+	SearchPRs(queryStr string, prCount int, after *string) ([]pr, pageInfo, error)
+	GetPRDetails(repoOwner, repoName string, prNumber int) (prDetails, error)
+	// GetPRTimeline fetches the last tlItemsCount timeline items before the
+	// before cursor; the most recent ones if it's nil
+	GetPRTimeline(repoOwner, repoName string, prNumber int, tlItemsCount int, before *string) ([]prTLItem, tlPageInfo, error)
+}
+
+// prDetailsPager is implemented by sources that can page through a PR's files,
+// commits and comments beyond the ones fetched with its details.
+type prDetailsPager interface {
+	GetPRFiles(repoOwner, repoName string, prNumber int, after *string) (prFiles, error)
+	GetPRCommits(repoOwner, repoName string, prNumber int, before *string) (prCommits, error)
+	GetPRComments(repoOwner, repoName string, prNumber int, after *string) (prComments, error)
+}
+
+// batchPRSource is implemented by sources that can fetch details and timelines
+// for several PRs in one request.
+type batchPRSource interface {
+	GetPRsData(prs []prRef, tlItemsCount int) ([]prData, error)
+}
+
+// rateLimitReporter is implemented by sources that know how much of their API
+// budget is left.
+type rateLimitReporter interface {
+	getRateLimit() *rateLimit
+}
+
+// prReviewer is implemented by sources that can submit reviews on PRs.
+type prReviewer interface {
+	SubmitPRReview(prID string, event PullRequestReviewEvent, body string) (string, error)
+}
+
+// prMerger is implemented by sources that can merge PRs.
+type prMerger interface {
+	MergePR(prID string, method PullRequestMergeMethod) error
+	EnablePRAutoMerge(prID string, method PullRequestMergeMethod) error
+}
+
+// reviewThreadSource is implemented by sources that can fetch and act on a
+// PR's review threads.
+type reviewThreadSource interface {
+	GetPRReviewThreads(repoOwner, repoName string, prNumber int) ([]prReviewThread, error)
+	ReplyToReviewThread(threadID string, body string) error
+	SetReviewThreadResolved(threadID string, resolved bool) error
+}
+
+// prMetadataEditor is implemented by sources that can change a PR's labels,
+// assignees, requested reviewers and milestone.
+type prMetadataEditor interface {
+	GetRepoMetadataOptions(repoOwner, repoName string) (repoMetadataOptions, error)
+	EditPRMetadata(prID string, edit prMetadataEdit) error
+	// RemoveReviewRequest takes back the review requested from the user with
+	// login, leaving the other review requests alone
+	RemoveReviewRequest(repoOwner, repoName string, prNumber int, login string) error
+}
+
+// checkRunSource is implemented by sources that can fetch the details of a
+// check run.
+type checkRunSource interface {
+	GetCheckRun(checkRunID string) (checkRun, error)
+	// GetJobLogTail fetches the last n lines of a Github Actions job's log
+	GetJobLogTail(repoOwner, repoName string, jobID int, n int) (string, error)
+}
+
+// prDiffSource is implemented by sources that can fetch a PR's unified diff.
+type prDiffSource interface {
+	GetPRDiff(repoOwner, repoName string, prNumber int) (string, error)
+}
+
+// checkSuiteRerequester is implemented by sources that can ask the app behind
+// a check suite to run it again.
+type checkSuiteRerequester interface {
+	RerequestCheckSuite(repoID, checkSuiteID string) error
+}
+
+// workflowRunRerunner is implemented by sources that can re-run the failed
+// jobs in a Github Actions workflow run.
+type workflowRunRerunner interface {
+	RerunFailedJobs(repoOwner, repoName string, workflowRunID int) error
+}
+
+// GHSource is a prDataSource backed by Github's GraphQL API, and its REST API
+// for what the former can't do.
+type GHSource struct {
+	client *rateLimitedClient
+	// host is what REST clients are set up for; an empty host uses the
+	// default host
+	host string
+}
+
+func NewGHSource(client *ghapi.GraphQLClient, host string) *GHSource {
+	return &GHSource{client: newRateLimitedClient(client), host: host}
+}
+
+func (s *GHSource) SearchPRs(queryStr string, prCount int, after *string) ([]pr, pageInfo, error) {
+	return getPRDataFromQuery(s.client, queryStr, prCount, after)
+}
+
+func (s *GHSource) GetPRDetails(repoOwner, repoName string, prNumber int) (prDetails, error) {
+	return getPRMetadata(s.client, repoOwner, repoName, prNumber)
+}
+
+func (s *GHSource) GetPRTimeline(repoOwner, repoName string, prNumber int, tlItemsCount int, before *string) ([]prTLItem, tlPageInfo, error) {
+	return getPRTLData(s.client, repoOwner, repoName, prNumber, tlItemsCount, before)
+}
+
+func (s *GHSource) GetPRFiles(repoOwner, repoName string, prNumber int, after *string) (prFiles, error) {
+	return getPRFiles(s.client, repoOwner, repoName, prNumber, after)
+}
+
+func (s *GHSource) GetPRCommits(repoOwner, repoName string, prNumber int, before *string) (prCommits, error) {
+	return getPRCommits(s.client, repoOwner, repoName, prNumber, before)
+}
+
+func (s *GHSource) GetPRComments(repoOwner, repoName string, prNumber int, after *string) (prComments, error) {
+	return getPRComments(s.client, repoOwner, repoName, prNumber, after)
+}
+
+func (s *GHSource) GetPRsData(prs []prRef, tlItemsCount int) ([]prData, error) {
+	return getPRsBatchData(s.client, prs, tlItemsCount)
+}
+
+func (s *GHSource) getRateLimit() *rateLimit {
+	return s.client.getRateLimit()
+}
+
+func (s *GHSource) SubmitPRReview(prID string, event PullRequestReviewEvent, body string) (string, error) {
+	return submitPRReview(s.client, prID, event, body)
+}
+
+func (s *GHSource) MergePR(prID string, method PullRequestMergeMethod) error {
+	return mergePR(s.client, prID, method)
+}
+
+func (s *GHSource) EnablePRAutoMerge(prID string, method PullRequestMergeMethod) error {
+	return enablePRAutoMerge(s.client, prID, method)
+}
+
+func (s *GHSource) GetPRReviewThreads(repoOwner, repoName string, prNumber int) ([]prReviewThread, error) {
+	return getPRReviewThreads(s.client, repoOwner, repoName, prNumber)
+}
+
+func (s *GHSource) ReplyToReviewThread(threadID string, body string) error {
+	return replyToReviewThread(s.client, threadID, body)
+}
+
+func (s *GHSource) SetReviewThreadResolved(threadID string, resolved bool) error {
+	return setReviewThreadResolved(s.client, threadID, resolved)
+}
+
+func (s *GHSource) GetRepoMetadataOptions(repoOwner, repoName string) (repoMetadataOptions, error) {
+	return getRepoMetadataOptions(s.client, repoOwner, repoName)
+}
+
+func (s *GHSource) EditPRMetadata(prID string, edit prMetadataEdit) error {
+	return editPRMetadata(s.client, prID, edit)
+}
+
+func (s *GHSource) RemoveReviewRequest(repoOwner, repoName string, prNumber int, login string) error {
+	client, err := newReviewRequestsClient(s.host)
+	if err != nil {
+		return err
+	}
+	return deleteReviewRequest(client, repoOwner, repoName, prNumber, login)
+}
+
+func (s *GHSource) GetCheckRun(checkRunID string) (checkRun, error) {
+	return getCheckRun(s.client, checkRunID)
+}
+
+func (s *GHSource) GetJobLogTail(repoOwner, repoName string, jobID int, n int) (string, error) {
+	client, err := newActionsClient(s.host)
+	if err != nil {
+		return "", err
+	}
+	return getJobLogTail(client, repoOwner, repoName, jobID, n)
+}
+
+func (s *GHSource) RerequestCheckSuite(repoID, checkSuiteID string) error {
+	return rerequestCheckSuite(s.client, repoID, checkSuiteID)
+}
+
+func (s *GHSource) RerunFailedJobs(repoOwner, repoName string, workflowRunID int) error {
+	client, err := newActionsClient(s.host)
+	if err != nil {
+		return err
+	}
+	return rerunFailedJobs(client, repoOwner, repoName, workflowRunID)
+}
+
+func (s *GHSource) GetPRDiff(repoOwner, repoName string, prNumber int) (string, error) {
+	client, err := newDiffClient(s.host)
+	if err != nil {
+		return "", err
+	}
+	return getPRDiff(client, repoOwner, repoName, prNumber)
+}
@@ -0,0 +1,115 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakePRSource struct {
+	prs         []pr
+	details     map[int]prDetails
+	tlItems     map[int][]prTLItem
+	lastQuery   string
+	numSearches int
+}
+
+// SearchPRs pages through s.prs, using the index of the next PR as the cursor.
+func (s *fakePRSource) SearchPRs(queryStr string, prCount int, after *string) ([]pr, pageInfo, error) {
+	s.lastQuery = queryStr
+	s.numSearches++
+
+	start := 0
+	if after != nil {
+		start, _ = strconv.Atoi(*after)
+	}
+	end := min(start+prCount, len(s.prs))
+
+	var pi pageInfo
+	if end < len(s.prs) {
+		cursor := strconv.Itoa(end)
+		pi = pageInfo{HasNextPage: true, EndCursor: &cursor}
+	}
+	return s.prs[start:end], pi, nil
+}
+
+func (s *fakePRSource) GetPRDetails(_, _ string, prNumber int) (prDetails, error) {
+	return s.details[prNumber], nil
+}
+
+func (s *fakePRSource) GetPRTimeline(_, _ string, prNumber int, _ int, _ *string) ([]prTLItem, tlPageInfo, error) {
+	return s.tlItems[prNumber], tlPageInfo{}, nil
+}
+
+// newTestModel returns a query mode model backed by src, with p (as a PR in
+// dhth/prs) as the only PR in its list.
+func newTestModel(t *testing.T, src prDataSource, p pr) Model {
+	t.Helper()
+
+	query := "type:pr author:@me"
+	m := InitialModel(src, Config{Query: &query}, QueryMode)
+	m.awaitingPRs = false
+
+	p.Repository.Owner.Login = "dhth"
+	p.Repository.Name = "prs"
+	p.URL = fmt.Sprintf("https://github.com/dhth/prs/pull/%d", p.Number)
+	m.setPRs([]pr{p})
+
+	return m
+}
+
+func TestFetchPRSForRepoUsesSource(t *testing.T) {
+	src := &fakePRSource{prs: []pr{{Number: 1}, {Number: 2}, {Number: 3}}}
+
+	msg := fetchPRSForRepo(src, "dhth", "prs", 2)()
+
+	got, ok := msg.(prsFetchedMsg)
+	require.True(t, ok)
+	require.NoError(t, got.err)
+	assert.Len(t, got.prs, 2)
+	assert.Equal(t, "type:pr repo:dhth/prs sort:updated-desc", src.lastQuery)
+}
+
+func TestFetchPRMetadataUsesSource(t *testing.T) {
+	src := &fakePRSource{details: map[int]prDetails{7: {Number: 7, PRTitle: "title"}}}
+
+	msg := fetchPRMetadata(src, "github.com/dhth/prs:7", "dhth", "prs", 7)()
+
+	got, ok := msg.(prMetadataFetchedMsg)
+	require.True(t, ok)
+	require.NoError(t, got.err)
+	assert.Equal(t, "github.com/dhth/prs:7", got.identifier)
+	assert.Equal(t, "title", got.metadata.PRTitle)
+}
+
+func TestPRsAreFetchedFromTheSourceForTheirHost(t *testing.T) {
+	src := &fakePRSource{details: map[int]prDetails{1: {PRTitle: "from github.com"}}}
+	ghesSrc := &fakePRSource{details: map[int]prDetails{2: {PRTitle: "from ghes"}}}
+	query := "type:pr author:@me"
+	m := InitialModel(src, Config{Query: &query, Host: "github.com"}, QueryMode)
+	m.hostSources["ghes.example.com"] = ghesSrc
+	// as if a repo on the other host was chosen after the PRs were listed
+	m.prSource = ghesSrc
+
+	prs := []pr{
+		{Number: 1, URL: "https://github.com/dhth/prs/pull/1"},
+		{Number: 2, URL: "https://ghes.example.com/dhth/prs/pull/2"},
+	}
+	batch, ok := m.enqueuePrefetch(prs)().(tea.BatchMsg)
+	require.True(t, ok)
+	require.Len(t, batch, 2)
+
+	var titles []string
+	for _, cmd := range batch {
+		msg, ok := cmd().(prsPrefetchedMsg)
+		require.True(t, ok)
+		require.NoError(t, msg.err)
+		require.Len(t, msg.data, 1)
+		titles = append(titles, msg.data[0].details.PRTitle)
+	}
+	assert.Equal(t, []string{"from github.com", "from ghes"}, titles)
+}
@@ -31,6 +31,22 @@ const (
 	helpViewTitleColor          = "#83a598"
 	toolNameColor               = "#b8bb26"
 	fetchingColor               = "#928374"
+	rateLimitColor              = "#d5c4a1"
+	rateLimitLowColor           = "#fb4934"
+	repoGroupHeaderColor        = "#83a598"
+	repoGroupCountColor         = "#665c54"
+	diffTitleColor              = "#8ec07c"
+	checkRunListColor           = "#fe8019"
+	checkRunTitleColor          = "#fe8019"
+	inactiveTabColor            = "#665c54"
+	composeTitleColor           = "#8ec07c"
+	confirmationColor           = "#fabd2f"
+	metadataPickerColor         = "#83a598"
+	prChangesColor              = "#fabd2f"
+	unreadDotColor              = "#83a598"
+	diffFileHeaderColor         = "#fabd2f"
+	diffHunkColor               = "#83a598"
+	diffMetaColor               = "#928374"
 )
 
 func getDynamicStyle(author string) lipgloss.Style {
@@ -72,6 +88,16 @@ var (
 			Bold(true).
 			Foreground(lipgloss.Color(helpMsgColor))
 
+	rateLimitStyle = func(low bool) lipgloss.Style {
+		st := baseStyle.
+			PaddingLeft(2)
+
+		if low {
+			return st.Bold(true).Foreground(lipgloss.Color(rateLimitLowColor))
+		}
+		return st.Foreground(lipgloss.Color(rateLimitColor))
+	}
+
 	dateStyle = lipgloss.NewStyle().
 			PaddingLeft(1).
 			Foreground(lipgloss.Color(dateColor))
@@ -150,4 +176,67 @@ var (
 
 	prDetailsTitleStyle = titleStyle.
 				Background(lipgloss.Color(prDetailsTitleColor))
+
+	prDiffTitleStyle = titleStyle.
+				Background(lipgloss.Color(diffTitleColor))
+
+	checkRunTitleStyle = titleStyle.
+				Background(lipgloss.Color(checkRunTitleColor))
+
+	diffFileHeaderStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color(diffFileHeaderColor))
+
+	diffHunkStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(diffHunkColor))
+
+	diffMetaStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(diffMetaColor))
+
+	diffAdditionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color(additionsColor))
+
+	diffDeletionStyle = lipgloss.NewStyle().
+				Foreground(lipgloss.Color(deletionsColor))
+
+	tabBarStyle = lipgloss.NewStyle().
+			PaddingTop(1).
+			PaddingLeft(2)
+
+	activeTabStyle = titleStyle.
+			Background(lipgloss.Color(prListColor))
+
+	inactiveTabStyle = titleStyle.
+				Bold(false).
+				Background(lipgloss.Color(inactiveTabColor))
+
+	composeTitleStyle = titleStyle.
+				Background(lipgloss.Color(composeTitleColor))
+
+	composeTAStyle = lipgloss.NewStyle().
+			PaddingLeft(2)
+
+	composeHintStyle = lipgloss.NewStyle().
+				PaddingLeft(2).
+				Foreground(lipgloss.Color(footerColor))
+
+	confirmationStyle = lipgloss.NewStyle().
+				Bold(true).
+				Foreground(lipgloss.Color(confirmationColor))
+
+	repoGroupHeaderStyle = lipgloss.NewStyle().
+				PaddingLeft(2).
+				Bold(true).
+				Foreground(lipgloss.Color(repoGroupHeaderColor))
+
+	repoGroupCountStyle = lipgloss.NewStyle().
+				PaddingLeft(2).
+				Foreground(lipgloss.Color(repoGroupCountColor))
+
+	unreadDotStyle = lipgloss.NewStyle().
+			Foreground(lipgloss.Color(unreadDotColor))
+
+	prChangesStyle = lipgloss.NewStyle().
+			PaddingLeft(1).
+			Foreground(lipgloss.Color(prChangesColor))
 )
@@ -0,0 +1,168 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+)
+
+// queryTab holds the state of the PR list for a named query. The state of the
+// active tab lives on the model itself, and is swapped in and out of its tab
+// when switching tabs.
+type queryTab struct {
+	name            string
+	query           string
+	prsList         list.Model
+	prCache         []*prResult
+	prsPageInfo     pageInfo
+	fetchingMorePRs bool
+	prsGeneration   int
+	awaitingPRs     bool
+	prsFromCache    bool
+	fetched         bool
+	// pendingMsgs holds results that arrived while the tab wasn't active;
+	// they're replayed once it is
+	pendingMsgs []tea.Msg
+}
+
+func getQueryTabs(config Config) []queryTab {
+	if len(config.Queries) == 0 {
+		var query string
+		if config.Query != nil {
+			query = *config.Query
+		}
+		return []queryTab{{query: query}}
+	}
+
+	tabs := make([]queryTab, len(config.Queries))
+	for i, q := range config.Queries {
+		tabs[i] = queryTab{name: q.Name, query: q.Query}
+	}
+	return tabs
+}
+
+func (m *Model) saveActiveTab() {
+	tab := &m.tabs[m.activeTab]
+	tab.prsList = m.prsList
+	tab.prCache = m.prCache
+	tab.prsPageInfo = m.prsPageInfo
+	tab.fetchingMorePRs = m.fetchingMorePRs
+	tab.prsGeneration = m.prsGeneration
+	tab.awaitingPRs = m.awaitingPRs
+	tab.prsFromCache = m.prsFromCache
+}
+
+func (m *Model) loadActiveTab() {
+	tab := m.tabs[m.activeTab]
+	m.prsList = tab.prsList
+	m.prCache = tab.prCache
+	m.prsPageInfo = tab.prsPageInfo
+	m.fetchingMorePRs = tab.fetchingMorePRs
+	m.prsGeneration = tab.prsGeneration
+	m.awaitingPRs = tab.awaitingPRs
+	m.prsFromCache = tab.prsFromCache
+}
+
+// switchTab activates the tab offset positions away from the active one,
+// fetching its PRs if that hasn't happened yet.
+func (m *Model) switchTab(offset int) tea.Cmd {
+	if len(m.tabs) <= 1 {
+		return nil
+	}
+
+	m.saveActiveTab()
+	m.activeTab = (m.activeTab + offset + len(m.tabs)) % len(m.tabs)
+	m.loadActiveTab()
+	m.prTLList.ResetSelected()
+	m.prDetailsCurSectionCache = make(map[string]uint)
+
+	// PRs might've been opened from other tabs in the meantime
+	for _, prRes := range m.prCache {
+		m.updateUnread(prRes)
+	}
+	m.setPRListItems()
+
+	tab := &m.tabs[m.activeTab]
+	var cmds []tea.Cmd
+	if !tab.fetched {
+		tab.fetched = true
+		m.awaitingPRs = true
+		cmds = append(cmds, loadCachedPRs(m.diskCache, m.prsHost(), tab.query))
+		cmds = append(cmds, fetchPRSFromQuery(m.prSource, tab.query, m.config.PRCount))
+	}
+
+	// deferred messages are replayed in the order they came in, so that eg. a
+	// page of PRs isn't added before the PRs it follows
+	replayed := make([]tea.Cmd, len(tab.pendingMsgs))
+	for i, msg := range tab.pendingMsgs {
+		replayed[i] = func() tea.Msg { return msg }
+	}
+	tab.pendingMsgs = nil
+	if len(replayed) > 0 {
+		cmds = append(cmds, tea.Sequence(replayed...))
+	}
+
+	return tea.Batch(cmds...)
+}
+
+// deferTabMsg holds on to a message meant for an inactive tab, and reports
+// whether such a tab was found.
+func (m *Model) deferTabMsg(query string, msg tea.Msg) bool {
+	for i := range m.tabs {
+		if i == m.activeTab || m.tabs[i].query != query {
+			continue
+		}
+		m.tabs[i].pendingMsgs = append(m.tabs[i].pendingMsgs, msg)
+		return true
+	}
+	return false
+}
+
+// resizeInactiveTabs applies a change in the terminal's dimensions to the
+// PR lists of inactive tabs.
+func (m *Model) resizeInactiveTabs(width, height int) {
+	for i := range m.tabs {
+		if i == m.activeTab {
+			continue
+		}
+
+		tab := &m.tabs[i]
+		tab.prsList.SetHeight(height)
+		tab.prsList.SetWidth(width)
+
+		for j := range tab.prCache {
+			tab.prCache[j].title = getPRTitle(tab.prCache[j].pr, tab.prCache[j].unread)
+			tab.prCache[j].description = getPRDesc(tab.prCache[j].pr, m.mode, m.terminalDetails)
+		}
+		showPRs(&tab.prsList, m.getPRListItems(tab.prCache))
+	}
+}
+
+func (m Model) showTabs() bool {
+	return m.mode == QueryMode && len(m.tabs) > 1
+}
+
+func (m Model) getTabBar() string {
+	tabs := make([]string, len(m.tabs))
+	for i, tab := range m.tabs {
+		numPRs := len(tab.prCache)
+		if i == m.activeTab {
+			numPRs = len(m.prCache)
+		}
+
+		label := tab.name
+		if tab.fetched || i == m.activeTab {
+			label = fmt.Sprintf("%s (%d)", tab.name, numPRs)
+		}
+
+		if i == m.activeTab {
+			tabs[i] = activeTabStyle.Render(label)
+		} else {
+			tabs[i] = inactiveTabStyle.Render(label)
+		}
+	}
+
+	return tabBarStyle.Render(strings.Join(tabs, " "))
+}
@@ -0,0 +1,74 @@
+package ui
+
+import (
+	"reflect"
+	"testing"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSwitchTabKeepsPerTabState(t *testing.T) {
+	src := &fakePRSource{}
+	config := Config{
+		PRCount: 10,
+		Queries: []NamedQuery{
+			{Name: "mine", Query: "type:pr author:@me"},
+			{Name: "review", Query: "type:pr review-requested:@me"},
+		},
+	}
+	m := InitialModel(src, config, QueryMode)
+	require.Len(t, m.tabs, 2)
+	assert.Equal(t, "type:pr author:@me", m.prsQuery())
+
+	m.setPRs([]pr{{Number: 1}, {Number: 2}})
+
+	cmd := m.switchTab(1)
+	assert.NotNil(t, cmd)
+	assert.Equal(t, "type:pr review-requested:@me", m.prsQuery())
+	assert.Empty(t, m.prCache)
+	assert.True(t, m.tabs[1].fetched)
+
+	m.switchTab(1)
+	assert.Equal(t, "type:pr author:@me", m.prsQuery())
+	assert.Len(t, m.prCache, 2)
+}
+
+func TestDeferTabMsgHoldsMsgsForInactiveTabs(t *testing.T) {
+	config := Config{
+		Queries: []NamedQuery{
+			{Name: "mine", Query: "q1"},
+			{Name: "review", Query: "q2"},
+		},
+	}
+	m := InitialModel(&fakePRSource{}, config, QueryMode)
+
+	assert.True(t, m.deferTabMsg("q2", prsFetchedMsg{query: "q2"}))
+	assert.False(t, m.deferTabMsg("q1", prsFetchedMsg{query: "q1"}))
+	assert.True(t, m.deferTabMsg("q2", morePRsFetchedMsg{query: "q2"}))
+	assert.Len(t, m.tabs[1].pendingMsgs, 2)
+
+	m.tabs[1].fetched = true
+	cmd := m.switchTab(1)
+	assert.Empty(t, m.tabs[1].pendingMsgs)
+
+	// they're replayed in order, rather than concurrently; bubbletea's
+	// sequence message isn't exported, so it's looked into via reflection
+	require.NotNil(t, cmd)
+	msg := cmd()
+	assert.NotEqual(t, reflect.TypeFor[tea.BatchMsg](), reflect.TypeOf(msg))
+	seq := reflect.ValueOf(msg)
+	require.Equal(t, reflect.Slice, seq.Kind())
+	require.Equal(t, 2, seq.Len())
+	assert.IsType(t, prsFetchedMsg{}, seq.Index(0).Interface().(tea.Cmd)())
+	assert.IsType(t, morePRsFetchedMsg{}, seq.Index(1).Interface().(tea.Cmd)())
+}
+
+func TestGetQueryTabsFallsBackToQuery(t *testing.T) {
+	query := "type:pr author:@me"
+	tabs := getQueryTabs(Config{Query: &query})
+
+	require.Len(t, tabs, 1)
+	assert.Equal(t, query, tabs[0].query)
+}
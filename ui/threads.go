@@ -0,0 +1,190 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/dustin/go-humanize"
+)
+
+func fetchReviewThreads(prSource prDataSource, identifier, repoOwner, repoName string, prNumber int) tea.Cmd {
+	return func() tea.Msg {
+		threadSource, ok := prSource.(reviewThreadSource)
+		if !ok {
+			return nil
+		}
+
+		threads, err := threadSource.GetPRReviewThreads(repoOwner, repoName, prNumber)
+		return reviewThreadsFetchedMsg{identifier, threads, err}
+	}
+}
+
+// fetchReviewThreadsIfNeeded fetches the review threads of the selected PR,
+// unless they've been fetched already.
+func (m *Model) fetchReviewThreadsIfNeeded() tea.Cmd {
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	if _, ok := m.reviewThreadsCache[prRes.identifier]; ok {
+		return nil
+	}
+
+	return fetchReviewThreads(m.prSourceForHost(getPRHost(prRes.pr)), prRes.identifier, prRes.pr.Repository.Owner.Login, prRes.pr.Repository.Name, prRes.pr.Number)
+}
+
+func findReviewThread(threads []prReviewThread, commentID string) *prReviewThread {
+	if commentID == "" {
+		return nil
+	}
+
+	for i := range threads {
+		for _, c := range threads[i].Comments.Nodes {
+			if c.ID == commentID {
+				return &threads[i]
+			}
+		}
+	}
+	return nil
+}
+
+// getCurrentReviewThread returns the thread the review comment being shown in
+// the timeline item detail view belongs to, if it's known.
+func (m Model) getCurrentReviewThread() *prReviewThread {
+	if m.activePane != prTLItemDetailView {
+		return nil
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	tlItem, ok := m.prTLList.SelectedItem().(*prTLItemResult)
+	if !ok || tlItem.item.Type != tlItemPRReview {
+		return nil
+	}
+
+	comments := tlItem.item.PullRequestReview.Comments.Nodes
+	if int(m.prRevCurCmtNum) >= len(comments) {
+		return nil
+	}
+
+	return findReviewThread(m.reviewThreadsCache[prRes.identifier], comments[m.prRevCurCmtNum].ID)
+}
+
+// getReviewThreadReplies renders the comments in a thread other than the one
+// with commentID.
+func getReviewThreadReplies(thread *prReviewThread, commentID string) string {
+	var replies []string
+	for _, c := range thread.Comments.Nodes {
+		if c.ID == commentID {
+			continue
+		}
+		replies = append(replies, fmt.Sprintf("#### @%s `%s`\n%s", c.Author.Login, humanize.Time(c.CreatedAt), c.Body))
+	}
+
+	if thread.Comments.TotalCount > len(thread.Comments.Nodes) {
+		replies = append(replies, fmt.Sprintf("> showing the first %d of %d comments in this thread", len(thread.Comments.Nodes), thread.Comments.TotalCount))
+	}
+
+	if len(replies) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("\n---\n## Thread\n%s", strings.Join(replies, "\n"))
+}
+
+func (m *Model) startReplyToReviewThread() tea.Cmd {
+	thread := m.getCurrentReviewThread()
+	if thread == nil {
+		m.message = "Review thread not available"
+		return nil
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	threadID, path, prSource := thread.ID, thread.Path, m.prSourceForHost(getPRHost(prRes.pr))
+	c := composer{
+		title:        fmt.Sprintf("Reply: %s (#%d)", path, prRes.pr.Number),
+		emptyBodyMsg: "Reply can't be empty",
+		onSubmit: func(body string) (string, string, tea.Cmd) {
+			return fmt.Sprintf("Reply to thread on %s?", path),
+				"replying...",
+				updateReviewThread(prSource, prRes, "reply", func(s reviewThreadSource) error {
+					return s.ReplyToReviewThread(threadID, body)
+				})
+		},
+	}
+
+	return m.startComposing(c, "Reply")
+}
+
+func (m *Model) toggleReviewThreadResolved() tea.Cmd {
+	thread := m.getCurrentReviewThread()
+	if thread == nil {
+		m.message = "Review thread not available"
+		return nil
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	threadID := thread.ID
+	resolve := !thread.IsResolved
+	action := "resolve"
+	m.message = "resolving thread..."
+	if !resolve {
+		action = "unresolve"
+		m.message = "unresolving thread..."
+	}
+
+	return updateReviewThread(m.prSourceForHost(getPRHost(prRes.pr)), prRes, action, func(s reviewThreadSource) error {
+		return s.SetReviewThreadResolved(threadID, resolve)
+	})
+}
+
+func updateReviewThread(prSource prDataSource, prRes *prResult, action string, update func(reviewThreadSource) error) tea.Cmd {
+	msg := reviewThreadUpdatedMsg{
+		identifier: prRes.identifier,
+		host:       getPRHost(prRes.pr),
+		repoOwner:  prRes.pr.Repository.Owner.Login,
+		repoName:   prRes.pr.Repository.Name,
+		prNumber:   prRes.pr.Number,
+		action:     action,
+	}
+
+	return func() tea.Msg {
+		threadSource, ok := prSource.(reviewThreadSource)
+		if !ok {
+			msg.err = errActionNotSupported
+			return msg
+		}
+
+		msg.err = update(threadSource)
+		return msg
+	}
+}
+
+// refreshReviewComment re-renders the review comment being shown, if any.
+func (m *Model) refreshReviewComment() {
+	if m.activePane != prTLItemDetailView {
+		return
+	}
+
+	tlItem, ok := m.prTLList.SelectedItem().(*prTLItemResult)
+	if !ok || tlItem.item.Type != tlItemPRReview || len(tlItem.item.PullRequestReview.Comments.Nodes) == 0 {
+		return
+	}
+
+	yOffset := m.prTLItemDetailVP.YOffset()
+	m.setPRReviewCmt(tlItem.item, m.prRevCurCmtNum)
+	m.prTLItemDetailVP.SetYOffset(yOffset)
+}
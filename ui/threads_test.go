@@ -0,0 +1,56 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestReviewThread(id string, comments ...string) prReviewThread {
+	var thread prReviewThread
+	thread.ID = id
+	for _, c := range comments {
+		var comment prReviewThreadComment
+		comment.ID = c
+		comment.Body = "body of " + c
+		comment.Author.Login = "dhth"
+		thread.Comments.Nodes = append(thread.Comments.Nodes, comment)
+	}
+	return thread
+}
+
+func TestFindReviewThread(t *testing.T) {
+	threads := []prReviewThread{
+		newTestReviewThread("PRRT_1", "C_1"),
+		newTestReviewThread("PRRT_2", "C_2", "C_3"),
+	}
+
+	got := findReviewThread(threads, "C_3")
+	require.NotNil(t, got)
+	assert.Equal(t, "PRRT_2", got.ID)
+
+	assert.Nil(t, findReviewThread(threads, "C_4"))
+	assert.Nil(t, findReviewThread(threads, ""))
+}
+
+func TestGetReviewThreadRepliesSkipsShownComment(t *testing.T) {
+	thread := newTestReviewThread("PRRT_1", "C_1", "C_2")
+
+	got := getReviewThreadReplies(&thread, "C_1")
+
+	assert.Contains(t, got, "body of C_2")
+	assert.NotContains(t, got, "body of C_1")
+
+	single := newTestReviewThread("PRRT_2", "C_3")
+	assert.Empty(t, getReviewThreadReplies(&single, "C_3"))
+}
+
+func TestGetReviewThreadRepliesNotesLeftOutComments(t *testing.T) {
+	thread := newTestReviewThread("PRRT_1", "C_1", "C_2")
+	thread.Comments.TotalCount = 60
+
+	got := getReviewThreadReplies(&thread, "C_1")
+
+	assert.Contains(t, got, "showing the first 2 of 60 comments in this thread")
+}
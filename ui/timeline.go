@@ -0,0 +1,226 @@
+package ui
+
+import (
+	"fmt"
+
+	"charm.land/bubbles/v2/key"
+	"charm.land/bubbles/v2/list"
+	tea "charm.land/bubbletea/v2"
+	"charm.land/lipgloss/v2"
+)
+
+const (
+	tlFilterOptions          = "r/c/a/x"
+	earlierTLItemsCount      = 100
+	fetchingEarlierTLTitle   = "fetching earlier timeline items..."
+	earlierTLItemsMarkerText = "(↑ for earlier items)"
+)
+
+func newPRTLItemResults(items []prTLItem) []*prTLItemResult {
+	results := make([]*prTLItemResult, len(items))
+	for i, item := range items {
+		results[i] = &prTLItemResult{
+			item:        &item,
+			title:       getPRTLItemTitle(&item),
+			description: getPRTLItemDesc(&item),
+		}
+	}
+	return results
+}
+
+type tlFilterKind uint
+
+const (
+	allTLItems tlFilterKind = iota
+	reviewTLItems
+	commitTLItems
+	actorTLItems
+)
+
+// tlFilter narrows down the items shown in the timeline list.
+type tlFilter struct {
+	kind  tlFilterKind
+	actor string
+}
+
+func (f tlFilter) matches(item *prTLItem) bool {
+	switch f.kind {
+	case reviewTLItems:
+		return item.Type == tlItemPRReview
+	case commitTLItems:
+		return item.Type == tlItemPRCommit || item.Type == tlItemHeadRefForcePushed
+	case actorTLItems:
+		return item.actor() == f.actor
+	default:
+		return true
+	}
+}
+
+func (f tlFilter) label() string {
+	switch f.kind {
+	case reviewTLItems:
+		return "reviews"
+	case commitTLItems:
+		return "commits"
+	case actorTLItems:
+		return "@" + f.actor
+	default:
+		return ""
+	}
+}
+
+// startTLFilter asks which timeline items are to be shown.
+func (m *Model) startTLFilter() {
+	m.askForChoice("Show only reviews, commits, or events by the selected item's actor? (x shows everything)", tlFilterOptions, func(m *Model, key string) tea.Cmd {
+		var filter tlFilter
+		switch key {
+		case "r":
+			filter = tlFilter{kind: reviewTLItems}
+		case "c":
+			filter = tlFilter{kind: commitTLItems}
+		case "a":
+			item, ok := m.prTLList.SelectedItem().(*prTLItemResult)
+			if !ok || item.item.actor() == "" {
+				m.message = "no timeline item selected"
+				return nil
+			}
+			filter = tlFilter{kind: actorTLItems, actor: item.item.actor()}
+		case "x":
+			// the zero value shows every item
+		default:
+			m.message = "cancelled"
+			return nil
+		}
+
+		m.prTLFilter = filter
+		if prRes, ok := m.prsList.SelectedItem().(*prResult); ok {
+			m.setPRTLListItems(prRes.identifier, prRes.pr.Number)
+		}
+		return nil
+	})
+}
+
+// setPRTLListItems shows the cached timeline items for the PR with identifier
+// that match the timeline filter, keeping the cursor on the item it was on,
+// if it's still shown.
+func (m *Model) setPRTLListItems(identifier string, prNumber int) {
+	selected, _ := m.prTLList.SelectedItem().(*prTLItemResult)
+
+	var items []list.Item
+	index := -1
+	for _, result := range m.prTLCache[identifier] {
+		if !m.prTLFilter.matches(result.item) {
+			continue
+		}
+		if result == selected {
+			index = len(items)
+		}
+		items = append(items, result)
+	}
+
+	m.prTLList.SetItems(items)
+	switch {
+	case index >= 0:
+		m.prTLList.Select(index)
+	case m.prTLList.Index() >= len(items):
+		m.prTLList.ResetSelected()
+	}
+	m.setPRTLListTitle(identifier, prNumber)
+}
+
+// setPRTLListTitle shows which PR's timeline is being shown, how it's
+// filtered, and whether there's more of it to be fetched.
+func (m *Model) setPRTLListTitle(identifier string, prNumber int) {
+	m.prTLList.Title = fmt.Sprintf("PR #%d Timeline", prNumber)
+	if label := m.prTLFilter.label(); label != "" {
+		m.prTLList.Title += fmt.Sprintf(" [%s]", label)
+	}
+	if m.prTLPageInfoCache[identifier].HasPreviousPage {
+		m.prTLList.Title += " " + earlierTLItemsMarkerText
+	}
+	m.prTLList.Styles.Title = m.prTLList.Styles.Title.Background(lipgloss.Color(prTLListColor))
+}
+
+// fetchEarlierTLItemsIfAtStart returns a command to fetch the timeline items
+// that precede the ones shown, if msg moved the cursor up while it was at the
+// start of the timeline, and there are such items.
+func (m *Model) fetchEarlierTLItemsIfAtStart(msg tea.KeyPressMsg) tea.Cmd {
+	if m.fetchingEarlierTLItems || m.prTLList.Index() != 0 {
+		return nil
+	}
+
+	keyMap := m.prTLList.KeyMap
+	if !key.Matches(msg, keyMap.CursorUp, keyMap.PrevPage, keyMap.GoToStart) {
+		return nil
+	}
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok {
+		return nil
+	}
+
+	pageInfo := m.prTLPageInfoCache[prRes.identifier]
+	if !pageInfo.HasPreviousPage || pageInfo.StartCursor == nil {
+		return nil
+	}
+
+	m.fetchingEarlierTLItems = true
+	m.prTLList.Title = fetchingEarlierTLTitle
+	m.prTLList.Styles.Title = m.prTLList.Styles.Title.Background(lipgloss.Color(fetchingColor))
+
+	return fetchEarlierPRTLItems(m.prSourceForHost(getPRHost(prRes.pr)),
+		prRes.identifier,
+		prRes.pr.Repository.Owner.Login,
+		prRes.pr.Repository.Name,
+		prRes.pr.Number,
+		earlierTLItemsCount,
+		pageInfo.StartCursor,
+	)
+}
+
+// addEarlierTLItems puts the timeline items in msg before the ones cached for
+// their PR, and shows them if the PR's timeline is being shown, keeping the
+// cursor on the item it was on.
+func (m *Model) addEarlierTLItems(msg earlierPRTLItemsFetchedMsg) {
+	tlItems, ok := m.prTLCache[msg.identifier]
+	if !ok {
+		// the cached timeline has been dropped in the meantime
+		return
+	}
+
+	earlier := newPRTLItemResults(msg.prTLItems)
+	m.prTLCache[msg.identifier] = append(earlier, tlItems...)
+	m.prTLPageInfoCache[msg.identifier] = msg.pageInfo
+
+	prRes, ok := m.prsList.SelectedItem().(*prResult)
+	if !ok || prRes.identifier != msg.identifier || m.activePane != prTLListView {
+		return
+	}
+
+	m.setPRTLListItems(msg.identifier, prRes.pr.Number)
+}
+
+// keepEarlierTLItems puts the items in prev, a PR's cached timeline, that
+// happened before the ones in cur, its latest timeline items as fetched again,
+// in front of them, so that earlier items that were fetched aren't lost. The
+// page info returned is that of the earliest item kept.
+func keepEarlierTLItems(prev []*prTLItemResult, prevPageInfo tlPageInfo, cur []prTLItem, curPageInfo tlPageInfo) ([]*prTLItemResult, tlPageInfo) {
+	items := newPRTLItemResults(cur)
+	if len(cur) == 0 {
+		return items, curPageInfo
+	}
+
+	earliest := cur[0].createdAt()
+	var earlier []*prTLItemResult
+	for _, item := range prev {
+		if !item.item.createdAt().Before(earliest) {
+			break
+		}
+		earlier = append(earlier, item)
+	}
+	if len(earlier) == 0 {
+		return items, curPageInfo
+	}
+
+	return append(earlier, items...), prevPageInfo
+}
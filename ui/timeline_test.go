@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"testing"
+	"time"
+
+	tea "charm.land/bubbletea/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func getIssueCommentTLItem(author string) prTLItem {
+	item := prTLItem{Type: tlItemIssueComment}
+	item.IssueComment.Author.Login = author
+	item.IssueComment.Body = "first line\nsecond line"
+	return item
+}
+
+func TestGetPRTLItemTitleAndDescForNewEventTypes(t *testing.T) {
+	comment := getIssueCommentTLItem("octocat")
+	assert.Contains(t, getPRTLItemTitle(&comment), "commented")
+	assert.Equal(t, "💬 first line", getPRTLItemDesc(&comment))
+
+	renamed := prTLItem{Type: tlItemRenamedTitleEvent}
+	renamed.RenamedTitleEvent.PreviousTitle = "wip"
+	renamed.RenamedTitleEvent.CurrentTitle = "add caching"
+	assert.Contains(t, getPRTLItemTitle(&renamed), "renamed the PR")
+	assert.Contains(t, getPRTLItemDesc(&renamed), "wip → add caching")
+
+	baseChanged := prTLItem{Type: tlItemBaseRefChangedEvent}
+	baseChanged.BaseRefChangedEvent.PreviousRefName = "main"
+	baseChanged.BaseRefChangedEvent.CurrentRefName = "release"
+	assert.Contains(t, getPRTLItemTitle(&baseChanged), "changed the base branch from main to release")
+
+	createdAt := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	labeled := prTLItem{Type: tlItemLabeledEvent}
+	labeled.LabeledEvent.CreatedAt = createdAt
+	assert.Equal(t, createdAt, labeled.createdAt())
+}
+
+func TestFetchingEarlierTLItems(t *testing.T) {
+	src := &fakePRSource{tlItems: map[int][]prTLItem{
+		1: {getIssueCommentTLItem("earlier")},
+	}}
+	m := newTestModel(t, src, pr{Number: 1})
+
+	identifier := m.prCache[0].identifier
+	cursor := "Y3Vyc29yOjE="
+	m.prTLCache[identifier] = newPRTLItemResults([]prTLItem{
+		getIssueCommentTLItem("first"),
+		getIssueCommentTLItem("second"),
+	})
+	m.prTLPageInfoCache[identifier] = tlPageInfo{HasPreviousPage: true, StartCursor: &cursor}
+
+	_, ok := m.setTL()
+	require.True(t, ok)
+	assert.Contains(t, m.prTLList.Title, earlierTLItemsMarkerText)
+
+	// moving down doesn't fetch anything
+	assert.Nil(t, m.fetchEarlierTLItemsIfAtStart(tea.KeyPressMsg{Code: 'j', Text: "j"}))
+
+	cmd := m.fetchEarlierTLItemsIfAtStart(tea.KeyPressMsg{Code: 'k', Text: "k"})
+	require.NotNil(t, cmd)
+	assert.True(t, m.fetchingEarlierTLItems)
+	assert.Equal(t, fetchingEarlierTLTitle, m.prTLList.Title)
+
+	// a fetch is in flight already
+	assert.Nil(t, m.fetchEarlierTLItemsIfAtStart(tea.KeyPressMsg{Code: 'k', Text: "k"}))
+
+	msg, ok := cmd().(earlierPRTLItemsFetchedMsg)
+	require.True(t, ok)
+	assert.Equal(t, identifier, msg.identifier)
+
+	updated, _ := m.Update(msg)
+	m = updated.(Model)
+	assert.False(t, m.fetchingEarlierTLItems)
+	require.Len(t, m.prTLCache[identifier], 3)
+	assert.Equal(t, "earlier", m.prTLCache[identifier][0].item.IssueComment.Author.Login)
+	assert.Len(t, m.prTLList.Items(), 3)
+	assert.Equal(t, 1, m.prTLList.Index())
+	assert.NotContains(t, m.prTLList.Title, earlierTLItemsMarkerText)
+
+	// there's nothing before the start of the timeline
+	m.prTLList.ResetSelected()
+	assert.Nil(t, m.fetchEarlierTLItemsIfAtStart(tea.KeyPressMsg{Code: 'k', Text: "k"}))
+}
+
+func TestFilteringTLItems(t *testing.T) {
+	m := newTestModel(t, &fakePRSource{}, pr{Number: 1})
+
+	review := prTLItem{Type: tlItemPRReview}
+	review.PullRequestReview.Author.Login = "reviewer"
+	commit := prTLItem{Type: tlItemPRCommit}
+	commit.PullRequestCommit.Commit.Author.Name = "someone"
+	forcePush := prTLItem{Type: tlItemHeadRefForcePushed}
+	forcePush.HeadRefForcePushed.Actor.Login = "reviewer"
+
+	identifier := m.prCache[0].identifier
+	m.prTLCache[identifier] = newPRTLItemResults([]prTLItem{commit, review, getIssueCommentTLItem("octocat"), forcePush})
+
+	_, ok := m.setTL()
+	require.True(t, ok)
+	require.Len(t, m.prTLList.Items(), 4)
+
+	getTypes := func() []string {
+		var types []string
+		for _, item := range m.prTLList.Items() {
+			types = append(types, item.(*prTLItemResult).item.Type)
+		}
+		return types
+	}
+
+	m.prTLList.Select(1)
+	m.startTLFilter()
+	m.handleConfirmation(tea.KeyPressMsg{Code: 'r', Text: "r"})
+	assert.Equal(t, []string{tlItemPRReview}, getTypes())
+	assert.Contains(t, m.prTLList.Title, "[reviews]")
+
+	m.startTLFilter()
+	m.handleConfirmation(tea.KeyPressMsg{Code: 'c', Text: "c"})
+	assert.Equal(t, []string{tlItemPRCommit, tlItemHeadRefForcePushed}, getTypes())
+
+	// the actor of the selected item is used
+	m.prTLList.Select(1)
+	m.startTLFilter()
+	m.handleConfirmation(tea.KeyPressMsg{Code: 'a', Text: "a"})
+	assert.Equal(t, []string{tlItemPRReview, tlItemHeadRefForcePushed}, getTypes())
+	assert.Contains(t, m.prTLList.Title, "[@reviewer]")
+
+	selected, ok := m.prTLList.SelectedItem().(*prTLItemResult)
+	require.True(t, ok)
+	assert.Equal(t, tlItemHeadRefForcePushed, selected.item.Type)
+
+	// the filter sticks around when a timeline is opened again
+	_, ok = m.setTL()
+	require.True(t, ok)
+	assert.Len(t, m.prTLList.Items(), 2)
+
+	m.startTLFilter()
+	m.handleConfirmation(tea.KeyPressMsg{Code: 'x', Text: "x"})
+	assert.Len(t, m.prTLList.Items(), 4)
+	assert.NotContains(t, m.prTLList.Title, "[")
+}
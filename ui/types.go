@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"net/url"
 	"strings"
 	"time"
 
@@ -21,6 +22,16 @@ const (
 	tlItemPRReview              = "PullRequestReview"
 	tlItemMergedEvent           = "MergedEvent"
 	tlItemHeadRefForcePushed    = "HeadRefForcePushedEvent"
+	tlItemIssueComment          = "IssueComment"
+	tlItemClosedEvent           = "ClosedEvent"
+	tlItemReopenedEvent         = "ReopenedEvent"
+	tlItemLabeledEvent          = "LabeledEvent"
+	tlItemUnlabeledEvent        = "UnlabeledEvent"
+	tlItemConvertToDraftEvent   = "ConvertToDraftEvent"
+	tlItemReviewDismissedEvent  = "ReviewDismissedEvent"
+	tlItemAssignedEvent         = "AssignedEvent"
+	tlItemRenamedTitleEvent     = "RenamedTitleEvent"
+	tlItemBaseRefChangedEvent   = "BaseRefChangedEvent"
 	reviewPending               = "PENDING"
 	reviewCommented             = "COMMENTED"
 	reviewApproved              = "APPROVED"
@@ -36,6 +47,7 @@ const (
 	statusStateFailure          = "FAILURE"
 	statusStateError            = "ERROR"
 	requestedReviewerUser       = "User"
+	requestedReviewerTeam       = "Team"
 	prDetailsMetadataKeyPadding = 30
 	checkNamePadding            = 40
 	statusConclusionPadding     = 16
@@ -49,8 +61,17 @@ const (
 	commentsCount               = 10
 	commitsCount                = 30
 	statusCheckContextsCount    = 50
+	reviewThreadsCount          = 100
+	threadCommentsCount         = 50
+	metadataOptionsCount        = 100
+	morePRDetailsCount          = 100
+	checkAnnotationsCount       = 50
+	searchPageSizeMax           = 100
 	timeFormat                  = "2006/01/02 15:04"
 	mergeableConflicting        = "CONFLICTING"
+	mergeableUnknown            = "UNKNOWN"
+	checksStatePending          = "PENDING"
+	checksStateExpected         = "EXPECTED"
 	noChecksHeader              = "## No Checks"
 )
 
@@ -59,18 +80,49 @@ type terminalDetails struct {
 }
 
 type SourceConfig struct {
-	DiffPager *string `yaml:"diff-pager"`
-	PRCount   *int    `yaml:"pr-count"`
-	Sources   *[]struct {
-		Owner string `yaml:"owner"`
-		Repos []struct {
-			Name string `yaml:"name"`
-		} `yaml:"repos"`
-	} `yaml:"sources"`
-	Query *string `yaml:"query"`
+	DiffPager     *string              `yaml:"diff-pager" mapstructure:"diff-pager"`
+	PRCount       *int                 `yaml:"pr-count" mapstructure:"pr-count"`
+	Sources       *[]OwnerSource       `yaml:"sources" mapstructure:"sources"`
+	Query         *string              `yaml:"query" mapstructure:"query"`
+	Queries       *[]NamedQuery        `yaml:"queries" mapstructure:"queries"`
+	Notifications *NotificationsConfig `yaml:"notifications" mapstructure:"notifications"`
+}
+
+// NotificationsConfig is how notifications are set up in the config file.
+type NotificationsConfig struct {
+	// Via is one of notify-send, osc9; notify-send is used if it's
+	// available, and OSC 9 otherwise, if it isn't set
+	Via   string                   `yaml:"via" mapstructure:"via"`
+	Rules []NotificationRuleConfig `yaml:"rules" mapstructure:"rules"`
+}
+
+// NotificationRuleConfig sends notifications for events on PRs in repos,
+// authored by authors; a rule without repos (or authors) applies to every
+// repo (or author).
+type NotificationRuleConfig struct {
+	Events  []string `yaml:"events" mapstructure:"events"`
+	Repos   []string `yaml:"repos" mapstructure:"repos"`
+	Authors []string `yaml:"authors" mapstructure:"authors"`
+}
+
+// NamedQuery is a search query shown as a tab in query mode.
+type NamedQuery struct {
+	Name  string `yaml:"name" mapstructure:"name"`
+	Query string `yaml:"query" mapstructure:"query"`
+}
+
+type OwnerSource struct {
+	Owner string `yaml:"owner" mapstructure:"owner"`
+	// Host is optional, and defaults to the host prs is configured to use
+	Host  string `yaml:"host" mapstructure:"host"`
+	Repos []struct {
+		Name string `yaml:"name" mapstructure:"name"`
+	} `yaml:"repos" mapstructure:"repos"`
 }
 
 type Repo struct {
+	// Host is empty for repos on the default host
+	Host  string
 	Owner string
 	Name  string
 }
@@ -79,6 +131,29 @@ type Config struct {
 	PRCount int
 	Repos   []Repo
 	Query   *string
+	// Queries are shown as tabs in query mode; Query is used if it's empty
+	Queries           []NamedQuery
+	PrefetchWorkers   int
+	PrefetchBatchSize int
+	CacheDir          string
+	// DiffPager is the command PR diffs are piped through; gh's pager is used
+	// if it's empty
+	DiffPager string
+	// StateFile is where state that outlives a session (eg. when PRs were
+	// last opened) is saved; it isn't saved if it's empty
+	StateFile string
+	// RefreshInterval is how often the PR list is refreshed in the
+	// background; it isn't if it's zero
+	RefreshInterval time.Duration
+	// Notifications are sent for events on PRs found by background refreshes;
+	// none are sent if it's nil
+	Notifications *Notifications
+	// Host is the host prs is configured to use; it's what PRs are fetched
+	// from in query mode
+	Host string
+	// HostSources holds the sources to use for repos not on the default host,
+	// keyed by host
+	HostSources map[string]*GHSource
 }
 
 type prResult struct {
@@ -86,6 +161,11 @@ type prResult struct {
 	title       string
 	description string
 	identifier  string
+	// changes holds what's changed about the PR since it was last looked at,
+	// as found by background refreshes
+	changes prChanges
+	// unread is set if the PR has had activity since it was last opened
+	unread bool
 }
 
 type prTLItemResult struct {
@@ -95,6 +175,7 @@ type prTLItemResult struct {
 }
 
 type pr struct {
+	ID         string
 	Number     int
 	PRTitle    string `graphql:"prTitle: title"`
 	Repository struct {
@@ -121,12 +202,54 @@ type pr struct {
 	Reviews   struct {
 		TotalCount int
 	}
+	Labels struct {
+		Nodes []prLabel
+	} `graphql:"labels(first: 10)"`
+	Commits struct {
+		TotalCount int
+	}
+	LastCommit struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					State string
+				}
+			}
+		}
+	} `graphql:"lastCommit: commits(last: 1)"`
+}
+
+func (p pr) checksFailed() bool {
+	switch p.checksState() {
+	case statusStateFailure, statusStateError:
+		return true
+	}
+	return false
+}
+
+// checksState returns the combined state of the checks on the PR's last
+// commit, if it has any.
+func (p pr) checksState() string {
+	if len(p.LastCommit.Nodes) == 0 || p.LastCommit.Nodes[0].Commit.StatusCheckRollup == nil {
+		return ""
+	}
+	return p.LastCommit.Nodes[0].Commit.StatusCheckRollup.State
+}
+
+// getPRHost returns the host a PR lives on, going by its URL.
+func getPRHost(pr *pr) string {
+	u, err := url.Parse(pr.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
 }
 
 type prDetails struct {
 	Number     int
 	PRTitle    string `graphql:"prTitle: title"`
 	Repository struct {
+		ID    string
 		Owner struct {
 			Login string
 		}
@@ -147,14 +270,7 @@ type prDetails struct {
 	Additions      int
 	Deletions      int
 	ReviewRequests *struct {
-		Nodes []struct {
-			RequestedReviewer *struct {
-				Type string `graphql:"type: __typename"`
-				User struct {
-					Login string
-				} `graphql:"... on User "`
-			}
-		}
+		Nodes []prReviewRequest
 	} `graphql:"reviewRequests (first:$reviewRequestsCount)"`
 	LatestReviews struct {
 		Nodes []struct {
@@ -164,23 +280,13 @@ type prDetails struct {
 			State string
 		}
 	} `graphql:"latestReviews (last: $latestReviewsCount)"`
-	Body  string
-	Files struct {
-		Nodes []struct {
-			Path      string
-			Additions int
-			Deletions int
-		}
-	} `graphql:"files (first: $filesCount)"`
+	Body   string
+	Files  prFiles `graphql:"files (first: $filesCount)"`
 	Labels struct {
-		Nodes []struct {
-			Name string
-		}
+		Nodes []prLabel
 	} `graphql:"labels (first: $labelsCount)"`
 	Assignees struct {
-		Nodes []struct {
-			Login string
-		}
+		Nodes []prUser
 	} `graphql:"assignees (first: $assigneesCount)"`
 	IssueReferences struct {
 		Nodes []struct {
@@ -194,59 +300,115 @@ type prDetails struct {
 			Login string
 		}
 	} `graphql:"participants (first: $participantsCount)"`
-	Comments struct {
-		TotalCount int
-		Nodes      []struct {
-			Body      string
-			UpdatedAt time.Time
-			Author    struct {
-				Login string
+	Comments prComments `graphql:"comments (first: $commentsCount)"`
+	Commits  prCommits  `graphql:"commits (last: $commitsCount)"`
+	MergedBy *struct {
+		Login string
+	}
+	Milestone  *prMilestone
+	LastCommit struct {
+		Nodes []prLastCommitNode
+	} `graphql:"lastCommit: commits(last: 1)"`
+}
+
+// prFiles, prCommits and prComments are the parts of a PR's details that can
+// be paged through beyond what's fetched along with them; commits are fetched
+// from the most recent one backwards.
+type prFiles struct {
+	TotalCount int
+	PageInfo   pageInfo
+	Nodes      []struct {
+		Path      string
+		Additions int
+		Deletions int
+	}
+}
+
+type prCommits struct {
+	TotalCount int
+	PageInfo   tlPageInfo
+	Nodes      []struct {
+		Commit struct {
+			AbbreviatedOid  string
+			MessageHeadline string
+			AuthoredDate    time.Time
+			Author          struct {
+				Name string
 			}
 		}
-	} `graphql:"comments (first: $commentsCount)"`
-	Commits struct {
-		TotalCount int
-		Nodes      []struct {
-			Commit struct {
-				AbbreviatedOid  string
-				MessageHeadline string
-				AuthoredDate    time.Time
-				Author          struct {
-					Name string
-				}
-			}
+	}
+}
+
+type prComments struct {
+	TotalCount int
+	PageInfo   pageInfo
+	Nodes      []struct {
+		Body      string
+		UpdatedAt time.Time
+		Author    struct {
+			Login string
 		}
-	} `graphql:"commits (last: $commitsCount)"`
-	MergedBy *struct {
-		Login string
 	}
-	Milestone *struct {
-		Title string
+}
+
+type prLabel struct {
+	ID   string
+	Name string
+}
+
+type prUser struct {
+	ID    string
+	Login string
+}
+
+type prMilestone struct {
+	ID    string
+	Title string
+}
+
+type prReviewRequest struct {
+	RequestedReviewer *prRequestedReviewer
+}
+
+type prRequestedReviewer struct {
+	Type string `graphql:"type: __typename"`
+	User prUser `graphql:"... on User "`
+	Team struct {
+		ID string
+	} `graphql:"... on Team "`
+}
+
+type prLastCommitNode struct {
+	Commit struct {
+		AbbreviatedOid    string
+		StatusCheckRollup *prStatusCheckRollup
 	}
-	LastCommit struct {
+}
+
+type prStatusCheckRollup struct {
+	Contexts struct {
 		Nodes []struct {
-			Commit struct {
-				AbbreviatedOid    string
-				StatusCheckRollup *struct {
-					Contexts struct {
-						Nodes []struct {
-							Type     string `graphql:"type: __typename"`
-							CheckRun struct {
-								Status     string
-								Conclusion *string
-								Name       string
-							} `graphql:"... on CheckRun"`
-							StatusContext struct {
-								State   string
-								Context string
-							} `graphql:"... on StatusContext"`
-						}
-					} `graphql:"contexts (first: $statusCheckContextsCount) "`
-					State string
+			Type     string `graphql:"type: __typename"`
+			CheckRun struct {
+				ID         string
+				Status     string
+				Conclusion *string
+				Name       string
+				DetailsURL *string
+				CheckSuite struct {
+					ID          string
+					WorkflowRun *struct {
+						DatabaseID int
+					}
 				}
-			}
+			} `graphql:"... on CheckRun"`
+			StatusContext struct {
+				State   string
+				Context string
+			} `graphql:"... on StatusContext"`
 		}
-	} `graphql:"lastCommit: commits(last: 1)"`
+	} `graphql:"contexts (first: $statusCheckContextsCount) "`
+	State string
 }
 
 type PRDetailSection uint
@@ -272,6 +434,7 @@ var PRDetailsSectionList = []PRDetailSection{
 }
 
 type prReviewComment struct {
+	ID        string
 	CreatedAt time.Time
 	Body      string
 	Outdated  bool
@@ -280,18 +443,46 @@ type prReviewComment struct {
 	URL       string
 }
 
+type prReviewThread struct {
+	ID         string
+	IsResolved bool
+	IsOutdated bool
+	Path       string
+	Comments   struct {
+		TotalCount int
+		Nodes      []prReviewThreadComment
+	} `graphql:"comments(first: $threadCommentsCount)"`
+}
+
+type prReviewThreadComment struct {
+	ID        string
+	Body      string
+	CreatedAt time.Time
+	Author    struct {
+		Login string
+	}
+}
+
+type pageInfo struct {
+	HasNextPage bool
+	EndCursor   *string
+}
+
 type prSearchQuery struct {
-	Search struct {
-		Edges []struct {
+	RateLimit rateLimit
+	Search    struct {
+		PageInfo pageInfo
+		Edges    []struct {
 			Node struct {
 				Type string `graphql:"type: __typename"`
 				pr   `graphql:"... on PullRequest"`
 			}
 		}
-	} `graphql:"search(query: $query, type: ISSUE, first: $count)"`
+	} `graphql:"search(query: $query, type: ISSUE, first: $count, after: $after)"`
 }
 
 type prDetailsQuery struct {
+	RateLimit       rateLimit
 	RepositoryOwner struct {
 		Repository struct {
 			PullRequest prDetails `graphql:"pullRequest(number: $pullRequestNumber)"`
@@ -368,20 +559,487 @@ type prTLItem struct {
 			Login string
 		}
 	} `graphql:"... on MergedEvent"`
+	IssueComment struct {
+		URL       string
+		CreatedAt time.Time
+		Body      string
+		Author    struct {
+			Login string
+		}
+	} `graphql:"... on IssueComment"`
+	ClosedEvent struct {
+		CreatedAt time.Time
+		Actor     struct {
+			Login string
+		}
+	} `graphql:"... on ClosedEvent"`
+	ReopenedEvent struct {
+		CreatedAt time.Time
+		Actor     struct {
+			Login string
+		}
+	} `graphql:"... on ReopenedEvent"`
+	LabeledEvent struct {
+		CreatedAt time.Time
+		Actor     struct {
+			Login string
+		}
+		Label struct {
+			Name string
+		}
+	} `graphql:"... on LabeledEvent"`
+	UnlabeledEvent struct {
+		CreatedAt time.Time
+		Actor     struct {
+			Login string
+		}
+		Label struct {
+			Name string
+		}
+	} `graphql:"... on UnlabeledEvent"`
+	ConvertToDraftEvent struct {
+		CreatedAt time.Time
+		Actor     struct {
+			Login string
+		}
+	} `graphql:"... on ConvertToDraftEvent"`
+	ReviewDismissedEvent struct {
+		CreatedAt        time.Time
+		DismissalMessage *string
+		Actor            struct {
+			Login string
+		}
+		Review *struct {
+			Author struct {
+				Login string
+			}
+		}
+	} `graphql:"... on ReviewDismissedEvent"`
+	AssignedEvent struct {
+		CreatedAt time.Time
+		Actor     struct {
+			Login string
+		}
+		Assignee struct {
+			User struct {
+				Login string
+			} `graphql:"... on User"`
+		}
+	} `graphql:"... on AssignedEvent"`
+	RenamedTitleEvent struct {
+		CreatedAt     time.Time
+		PreviousTitle string
+		CurrentTitle  string
+		Actor         struct {
+			Login string
+		}
+	} `graphql:"... on RenamedTitleEvent"`
+	BaseRefChangedEvent struct {
+		CreatedAt       time.Time
+		PreviousRefName string
+		CurrentRefName  string
+		Actor           struct {
+			Login string
+		}
+	} `graphql:"... on BaseRefChangedEvent"`
+}
+
+// createdAt returns when the timeline item happened.
+func (item prTLItem) createdAt() time.Time {
+	switch item.Type {
+	case tlItemPRCommit:
+		return item.PullRequestCommit.Commit.CommittedDate
+	case tlItemHeadRefForcePushed:
+		return item.HeadRefForcePushed.CreatedAt
+	case tlItemPRReadyForReview:
+		return item.PullRequestReadyForReview.CreatedAt
+	case tlItemPRReviewRequested:
+		return item.PullRequestReviewRequested.CreatedAt
+	case tlItemPRReview:
+		return item.PullRequestReview.CreatedAt
+	case tlItemMergedEvent:
+		return item.MergedEvent.CreatedAt
+	case tlItemIssueComment:
+		return item.IssueComment.CreatedAt
+	case tlItemClosedEvent:
+		return item.ClosedEvent.CreatedAt
+	case tlItemReopenedEvent:
+		return item.ReopenedEvent.CreatedAt
+	case tlItemLabeledEvent:
+		return item.LabeledEvent.CreatedAt
+	case tlItemUnlabeledEvent:
+		return item.UnlabeledEvent.CreatedAt
+	case tlItemConvertToDraftEvent:
+		return item.ConvertToDraftEvent.CreatedAt
+	case tlItemReviewDismissedEvent:
+		return item.ReviewDismissedEvent.CreatedAt
+	case tlItemAssignedEvent:
+		return item.AssignedEvent.CreatedAt
+	case tlItemRenamedTitleEvent:
+		return item.RenamedTitleEvent.CreatedAt
+	case tlItemBaseRefChangedEvent:
+		return item.BaseRefChangedEvent.CreatedAt
+	default:
+		return time.Time{}
+	}
+}
+
+// actor returns the login of whoever the timeline item is from; commits by
+// authors without a Github account are attributed to the author's name.
+func (item prTLItem) actor() string {
+	switch item.Type {
+	case tlItemPRCommit:
+		if item.PullRequestCommit.Commit.Author.User != nil {
+			return item.PullRequestCommit.Commit.Author.User.Login
+		}
+		return item.PullRequestCommit.Commit.Author.Name
+	case tlItemHeadRefForcePushed:
+		return item.HeadRefForcePushed.Actor.Login
+	case tlItemPRReadyForReview:
+		return item.PullRequestReadyForReview.Actor.Login
+	case tlItemPRReviewRequested:
+		return item.PullRequestReviewRequested.Actor.Login
+	case tlItemPRReview:
+		return item.PullRequestReview.Author.Login
+	case tlItemMergedEvent:
+		return item.MergedEvent.Actor.Login
+	case tlItemIssueComment:
+		return item.IssueComment.Author.Login
+	case tlItemClosedEvent:
+		return item.ClosedEvent.Actor.Login
+	case tlItemReopenedEvent:
+		return item.ReopenedEvent.Actor.Login
+	case tlItemLabeledEvent:
+		return item.LabeledEvent.Actor.Login
+	case tlItemUnlabeledEvent:
+		return item.UnlabeledEvent.Actor.Login
+	case tlItemConvertToDraftEvent:
+		return item.ConvertToDraftEvent.Actor.Login
+	case tlItemReviewDismissedEvent:
+		return item.ReviewDismissedEvent.Actor.Login
+	case tlItemAssignedEvent:
+		return item.AssignedEvent.Actor.Login
+	case tlItemRenamedTitleEvent:
+		return item.RenamedTitleEvent.Actor.Login
+	case tlItemBaseRefChangedEvent:
+		return item.BaseRefChangedEvent.Actor.Login
+	default:
+		return ""
+	}
+}
+
+// tlPageInfo is used to page backwards through a PR's timeline, as it's
+// fetched most recent items first.
+type tlPageInfo struct {
+	HasPreviousPage bool
+	StartCursor     *string
+}
+
+type prTimeline struct {
+	TimelineItems struct {
+		PageInfo tlPageInfo
+		Nodes    []prTLItem
+	} `graphql:"timelineItems(last: $timelineItemsCount, before: $timelineItemsBefore, itemTypes: [PULL_REQUEST_COMMIT, READY_FOR_REVIEW_EVENT, REVIEW_REQUESTED_EVENT, MERGED_EVENT, PULL_REQUEST_REVIEW, HEAD_REF_FORCE_PUSHED_EVENT, ISSUE_COMMENT, CLOSED_EVENT, REOPENED_EVENT, LABELED_EVENT, UNLABELED_EVENT, CONVERT_TO_DRAFT_EVENT, REVIEW_DISMISSED_EVENT, ASSIGNED_EVENT, RENAMED_TITLE_EVENT, BASE_REF_CHANGED_EVENT])"`
 }
 
 type prTLQuery struct {
+	RateLimit       rateLimit
+	RepositoryOwner struct {
+		Repository struct {
+			PullRequest prTimeline `graphql:"pullRequest(number: $pullRequestNumber)"`
+		} `graphql:"repository(name: $repositoryName)"`
+	} `graphql:"repositoryOwner(login: $repositoryOwner)"`
+}
+
+// PullRequestReviewEvent is exported only because shurcooL-graphql derives
+// the type of a mutation's variables from the name of their Go type.
+type PullRequestReviewEvent string
+
+const (
+	reviewApprove        PullRequestReviewEvent = "APPROVE"
+	reviewRequestChanges PullRequestReviewEvent = "REQUEST_CHANGES"
+	reviewComment        PullRequestReviewEvent = "COMMENT"
+)
+
+func (e PullRequestReviewEvent) label() string {
+	switch e {
+	case reviewApprove:
+		return "Approve"
+	case reviewRequestChanges:
+		return "Request changes"
+	default:
+		return "Comment"
+	}
+}
+
+// bodyRequired reports whether Github requires a body for reviews of this
+// kind.
+func (e PullRequestReviewEvent) bodyRequired() bool {
+	return e != reviewApprove
+}
+
+type addPRReviewMutation struct {
+	AddPullRequestReview struct {
+		PullRequestReview struct {
+			ID    string
+			State string
+		}
+	} `graphql:"addPullRequestReview(input: {pullRequestId: $pullRequestId, event: $event, body: $body})"`
+}
+
+// PullRequestMergeMethod is exported for the same reason as
+// PullRequestReviewEvent.
+type PullRequestMergeMethod string
+
+const (
+	mergeMethodMerge  PullRequestMergeMethod = "MERGE"
+	mergeMethodSquash PullRequestMergeMethod = "SQUASH"
+	mergeMethodRebase PullRequestMergeMethod = "REBASE"
+)
+
+func (mm PullRequestMergeMethod) label() string {
+	switch mm {
+	case mergeMethodSquash:
+		return "squash"
+	case mergeMethodRebase:
+		return "rebase"
+	default:
+		return "merge"
+	}
+}
+
+type mergePRMutation struct {
+	MergePullRequest struct {
+		PullRequest struct {
+			State string
+		}
+	} `graphql:"mergePullRequest(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod})"`
+}
+
+type enablePRAutoMergeMutation struct {
+	EnablePullRequestAutoMerge struct {
+		PullRequest struct {
+			State string
+		}
+	} `graphql:"enablePullRequestAutoMerge(input: {pullRequestId: $pullRequestId, mergeMethod: $mergeMethod})"`
+}
+
+type prFilesQuery struct {
+	RateLimit       rateLimit
+	RepositoryOwner struct {
+		Repository struct {
+			PullRequest struct {
+				Files prFiles `graphql:"files(first: $filesCount, after: $filesAfter)"`
+			} `graphql:"pullRequest(number: $pullRequestNumber)"`
+		} `graphql:"repository(name: $repositoryName)"`
+	} `graphql:"repositoryOwner(login: $repositoryOwner)"`
+}
+
+type prCommitsQuery struct {
+	RateLimit       rateLimit
+	RepositoryOwner struct {
+		Repository struct {
+			PullRequest struct {
+				Commits prCommits `graphql:"commits(last: $commitsCount, before: $commitsBefore)"`
+			} `graphql:"pullRequest(number: $pullRequestNumber)"`
+		} `graphql:"repository(name: $repositoryName)"`
+	} `graphql:"repositoryOwner(login: $repositoryOwner)"`
+}
+
+type prCommentsQuery struct {
+	RateLimit       rateLimit
+	RepositoryOwner struct {
+		Repository struct {
+			PullRequest struct {
+				Comments prComments `graphql:"comments(first: $commentsCount, after: $commentsAfter)"`
+			} `graphql:"pullRequest(number: $pullRequestNumber)"`
+		} `graphql:"repository(name: $repositoryName)"`
+	} `graphql:"repositoryOwner(login: $repositoryOwner)"`
+}
+
+type checkRunQuery struct {
+	RateLimit rateLimit
+	Node      struct {
+		CheckRun checkRun `graphql:"... on CheckRun"`
+	} `graphql:"node(id: $checkRunId)"`
+}
+
+type checkRun struct {
+	ID          string
+	DatabaseID  *int
+	Name        string
+	Status      string
+	Conclusion  *string
+	Title       *string
+	Summary     *string
+	DetailsURL  *string
+	StartedAt   *time.Time
+	CompletedAt *time.Time
+	CheckSuite  struct {
+		App *struct {
+			Slug string
+		}
+	}
+	Annotations struct {
+		TotalCount int
+		Nodes      []checkAnnotation
+	} `graphql:"annotations(first: $annotationsCount)"`
+}
+
+type checkAnnotation struct {
+	Path     string
+	Location struct {
+		Start struct {
+			Line int
+		}
+		End struct {
+			Line int
+		}
+	}
+	AnnotationLevel *string
+	Title           *string
+	Message         string
+}
+
+type rerequestCheckSuiteMutation struct {
+	RerequestCheckSuite struct {
+		CheckSuite struct {
+			ID string
+		}
+	} `graphql:"rerequestCheckSuite(input: {repositoryId: $repositoryId, checkSuiteId: $checkSuiteId})"`
+}
+
+type prReviewThreadsQuery struct {
+	RateLimit       rateLimit
 	RepositoryOwner struct {
 		Repository struct {
 			PullRequest struct {
-				TimelineItems struct {
-					Nodes []prTLItem
-				} `graphql:"timelineItems(last: $timelineItemsCount, itemTypes: [PULL_REQUEST_COMMIT, READY_FOR_REVIEW_EVENT, REVIEW_REQUESTED_EVENT, MERGED_EVENT, PULL_REQUEST_REVIEW, HEAD_REF_FORCE_PUSHED_EVENT])"`
+				ReviewThreads struct {
+					PageInfo pageInfo
+					Nodes    []prReviewThread
+				} `graphql:"reviewThreads(first: $reviewThreadsCount, after: $reviewThreadsAfter)"`
 			} `graphql:"pullRequest(number: $pullRequestNumber)"`
 		} `graphql:"repository(name: $repositoryName)"`
 	} `graphql:"repositoryOwner(login: $repositoryOwner)"`
 }
 
+type replyToReviewThreadMutation struct {
+	AddPullRequestReviewThreadReply struct {
+		Comment struct {
+			ID string
+		}
+	} `graphql:"addPullRequestReviewThreadReply(input: {pullRequestReviewThreadId: $threadId, body: $body})"`
+}
+
+type resolveReviewThreadMutation struct {
+	ResolveReviewThread struct {
+		Thread struct {
+			IsResolved bool
+		}
+	} `graphql:"resolveReviewThread(input: {threadId: $threadId})"`
+}
+
+type unresolveReviewThreadMutation struct {
+	UnresolveReviewThread struct {
+		Thread struct {
+			IsResolved bool
+		}
+	} `graphql:"unresolveReviewThread(input: {threadId: $threadId})"`
+}
+
+type prMetadataKind uint
+
+const (
+	labelsMetadata prMetadataKind = iota
+	assigneesMetadata
+	reviewersMetadata
+	milestoneMetadata
+)
+
+func (k prMetadataKind) label() string {
+	switch k {
+	case assigneesMetadata:
+		return "assignees"
+	case reviewersMetadata:
+		return "reviewers"
+	case milestoneMetadata:
+		return "milestone"
+	default:
+		return "labels"
+	}
+}
+
+// prMetadataEdit adds (or removes) a single label, assignee, reviewer or
+// milestone to (or from) a PR.
+type prMetadataEdit struct {
+	kind   prMetadataKind
+	option metadataOption
+	add    bool
+}
+
+type metadataOption struct {
+	id   string
+	name string
+}
+
+// repoMetadataOptions holds what a repo's PRs can be labelled with, assigned
+// to, requested reviews from, and put in.
+type repoMetadataOptions struct {
+	Labels struct {
+		Nodes []prLabel
+	} `graphql:"labels(first: $metadataOptionsCount)"`
+	AssignableUsers struct {
+		Nodes []prUser
+	} `graphql:"assignableUsers(first: $metadataOptionsCount)"`
+	Milestones struct {
+		Nodes []prMilestone
+	} `graphql:"milestones(first: $metadataOptionsCount, states: OPEN)"`
+}
+
+type repoMetadataOptionsQuery struct {
+	RepositoryOwner struct {
+		Repository repoMetadataOptions `graphql:"repository(name: $repositoryName)"`
+	} `graphql:"repositoryOwner(login: $repositoryOwner)"`
+}
+
+type addLabelsMutation struct {
+	AddLabelsToLabelable struct {
+		ClientMutationID *string
+	} `graphql:"addLabelsToLabelable(input: {labelableId: $pullRequestId, labelIds: $ids})"`
+}
+
+type removeLabelsMutation struct {
+	RemoveLabelsFromLabelable struct {
+		ClientMutationID *string
+	} `graphql:"removeLabelsFromLabelable(input: {labelableId: $pullRequestId, labelIds: $ids})"`
+}
+
+type addAssigneesMutation struct {
+	AddAssigneesToAssignable struct {
+		ClientMutationID *string
+	} `graphql:"addAssigneesToAssignable(input: {assignableId: $pullRequestId, assigneeIds: $ids})"`
+}
+
+type removeAssigneesMutation struct {
+	RemoveAssigneesFromAssignable struct {
+		ClientMutationID *string
+	} `graphql:"removeAssigneesFromAssignable(input: {assignableId: $pullRequestId, assigneeIds: $ids})"`
+}
+
+// requestReviewsMutation adds to the set of requested reviewers; review
+// requests can only be taken back via the REST API.
+type requestReviewsMutation struct {
+	RequestReviews struct {
+		ClientMutationID *string
+	} `graphql:"requestReviews(input: {pullRequestId: $pullRequestId, userIds: $ids, union: true})"`
+}
+
+type setMilestoneMutation struct {
+	UpdatePullRequest struct {
+		ClientMutationID *string
+	} `graphql:"updatePullRequest(input: {pullRequestId: $pullRequestId, milestoneId: $milestoneId})"`
+}
+
 func (pr prDetails) Metadata() string {
 	var metadata []string
 
@@ -581,7 +1239,10 @@ func (pr prDetails) Checks() string {
 					conclusionMarker,
 				))
 			} else {
-				checks = append(checks, fmt.Sprintf("- %s %s", checkName, n.CheckRun.Status))
+				checks = append(checks, fmt.Sprintf("- %s %s ⏳",
+					checkName,
+					RightPadTrim(fmt.Sprintf("`%s`", n.CheckRun.Status), statusConclusionPadding),
+				))
 			}
 		case statusContextType:
 			var stateMarker string
@@ -641,10 +1302,16 @@ func (pr prDetails) FilesChanged() string {
 
 		fc[i] = fmt.Sprintf("- %s%s%s", f.Path, additions, deletions)
 	}
+
+	var filesNumStr string
+	if len(pr.Files.Nodes) < pr.Files.TotalCount {
+		filesNumStr = fmt.Sprintf(" (first %d out of %d)", len(pr.Files.Nodes), pr.Files.TotalCount)
+	}
+
 	return fmt.Sprintf(`
-## Files changed
+## Files changed%s
 
-%s`, strings.Join(fc, "\n"))
+%s`, filesNumStr, strings.Join(fc, "\n"))
 }
 
 func (pr prDetails) CommitsList() string {
@@ -699,6 +1366,9 @@ func (repo Repo) Title() string {
 }
 
 func (repo Repo) Description() string {
+	if repo.Host != "" {
+		return fmt.Sprintf("%s (%s)", repo.Owner, repo.Host)
+	}
 	return repo.Owner
 }
 
@@ -706,7 +1376,46 @@ func (repo Repo) FilterValue() string {
 	return fmt.Sprintf("%s:::%s", repo.Owner, repo.Name)
 }
 
+type repoGroupHeader struct {
+	host     string
+	owner    string
+	numRepos int
+}
+
+func (h repoGroupHeader) Title() string {
+	if h.host != "" {
+		return fmt.Sprintf("%s (%s)", h.owner, h.host)
+	}
+	return h.owner
+}
+
+func (h repoGroupHeader) Description() string {
+	if h.numRepos == 1 {
+		return "1 repo"
+	}
+	return fmt.Sprintf("%d repos", h.numRepos)
+}
+
+func (h repoGroupHeader) FilterValue() string {
+	return h.owner
+}
+
+func (c Config) sourceForHost(prSource prDataSource, host string) prDataSource {
+	if host == "" {
+		return prSource
+	}
+
+	hostSource, ok := c.HostSources[host]
+	if !ok {
+		return prSource
+	}
+	return hostSource
+}
+
 func (prRes prResult) Title() string {
+	if prRes.changes != 0 {
+		return prRes.title + prChangesStyle.Render("↻ "+prRes.changes.String())
+	}
 	return prRes.title
 }
 
@@ -714,8 +1423,20 @@ func (prRes prResult) Description() string {
 	return prRes.description
 }
 
+// FilterValue is what the PR list's filter is matched against: the PR's
+// number, title, author, repo and labels.
 func (prRes prResult) FilterValue() string {
-	return fmt.Sprintf("%d", prRes.pr.Number)
+	p := prRes.pr
+	parts := []string{
+		fmt.Sprintf("#%d", p.Number),
+		p.PRTitle,
+		p.Author.Login,
+		fmt.Sprintf("%s/%s", p.Repository.Owner.Login, p.Repository.Name),
+	}
+	for _, l := range p.Labels.Nodes {
+		parts = append(parts, l.Name)
+	}
+	return strings.Join(parts, " ")
 }
 
 func (ir prTLItemResult) Title() string {
@@ -5,10 +5,9 @@ import (
 	"os"
 
 	tea "charm.land/bubbletea/v2"
-	ghapi "github.com/cli/go-gh/v2/pkg/api"
 )
 
-func RenderUI(ghClient *ghapi.GraphQLClient, config Config, mode Mode) error {
+func RenderUI(prSource prDataSource, config Config, mode Mode) error {
 	if len(os.Getenv("DEBUG")) > 0 {
 		f, err := tea.LogToFile("debug.log", "debug")
 		if err != nil {
@@ -17,7 +16,7 @@ func RenderUI(ghClient *ghapi.GraphQLClient, config Config, mode Mode) error {
 		}
 		defer f.Close()
 	}
-	p := tea.NewProgram(InitialModel(ghClient, config, mode))
+	p := tea.NewProgram(InitialModel(prSource, config, mode))
 	_, err := p.Run()
 	return err
 }
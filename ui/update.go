@@ -32,6 +32,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	switch msg := msg.(type) {
 	case tea.KeyPressMsg:
+		if m.confirmation != nil {
+			return m, m.handleConfirmation(msg)
+		}
+
+		if m.activePane == composeView {
+			return m.updateComposeView(msg)
+		}
+
+		if m.activePane == metadataPickerView {
+			return m.updateMetadataPickerView(msg)
+		}
+
+		if m.activePane == checkRunListView {
+			return m.updateCheckRunListView(msg)
+		}
+
+		if m.activePane == prListView && m.prsList.FilterState() == list.Filtering {
+			m.prsList, cmd = m.prsList.Update(msg)
+			return m, cmd
+		}
+
 		switch msg.String() {
 		case "Q":
 			return m, tea.Quit
@@ -44,6 +65,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.activePane = m.lastPane
 			case helpView:
 				m.activePane = m.lastPane
+			case prDiffView:
+				m.activePane = m.lastPane
+			case checkRunDetailView:
+				m.checkRunVP.GotoTop()
+				m.activePane = checkRunListView
 			case prTLItemDetailView:
 				m.prTLItemDetailVP.GotoTop()
 				m.activePane = prTLListView
@@ -59,6 +85,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.activePane = m.lastPane
 				m.lastPane = prDetailsView
 			case prListView:
+				if msg.String() == "esc" && m.prsList.FilterState() == list.FilterApplied {
+					m.clearPRFilter()
+					break
+				}
 				if m.mode == RepoMode {
 					m.activePane = repoListView
 					m.repoChosen = false
@@ -75,10 +105,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 				switch m.mode {
 				case RepoMode:
-					cmds = append(cmds, fetchPRSForRepo(m.ghClient, m.repoOwner, m.repoName, m.config.PRCount))
+					cmds = append(cmds, fetchPRSForRepo(m.prSource, m.repoOwner, m.repoName, m.config.PRCount))
 				case QueryMode:
-					cmds = append(cmds, fetchPRSFromQuery(m.ghClient, *m.config.Query, m.config.PRCount))
+					cmds = append(cmds, fetchPRSFromQuery(m.prSource, m.prsQuery(), m.config.PRCount))
 				}
+				m.prsPageInfo = pageInfo{}
+				m.awaitingPRs = true
+				m.fetchingMorePRs = false
+				m.prsGeneration++
 				m.prsList.Title = fetchingPRsTitle
 				m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(fetchingColor))
 
@@ -91,7 +125,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				repoOwner := pr.pr.Repository.Owner.Login
 				repoName := pr.pr.Repository.Name
 				prNumber := pr.pr.Number
-				cmds = append(cmds, fetchPRTLItems(m.ghClient, repoOwner, repoName, prNumber, 100, true))
+				cmds = append(cmds, fetchPRTLItems(m.prSourceForHost(getPRHost(pr.pr)), pr.identifier, repoOwner, repoName, prNumber, 100, true))
 				m.prTLList.Title = "fetching timeline..."
 				m.prTLList.Styles.Title = m.prTLList.Styles.Title.Background(lipgloss.Color(fetchingColor))
 			}
@@ -109,7 +143,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		case "enter":
 			switch m.activePane {
+			case prDetailsView:
+				if PRDetailsSectionList[m.prDetailsCurrentSection] == PRChecks {
+					m.showCheckRuns()
+				}
 			case prListView:
+				if m.togglePRGroup() {
+					break
+				}
+
 				setTlCmd, ok := m.setTL()
 				if !ok {
 					m.message = couldntGetPRDetailsMsg
@@ -135,11 +177,13 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.setPRReviewCmt(item.item, 0)
 				m.prRevCurCmtNum = 0
 				m.activePane = prTLItemDetailView
+				m.refreshReviewComment()
+				cmds = append(cmds, m.fetchReviewThreadsIfNeeded())
 
 			case repoListView:
-				selected := m.repoList.SelectedItem()
-				if selected != nil {
-					cmds = append(cmds, chooseRepo(selected.FilterValue()))
+				selected, ok := m.repoList.SelectedItem().(Repo)
+				if ok {
+					cmds = append(cmds, chooseRepo(selected))
 				}
 			}
 		case "2":
@@ -151,6 +195,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			case prDetailsView:
 				m.GoToPRDetailSection(1)
 			default:
+				if m.prGroupHeaderSelected() {
+					break
+				}
+
 				setTlCmd, ok := m.setTL()
 				if !ok {
 					m.message = "Could't get repo/pr details. Inform @dhth on github."
@@ -185,7 +233,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 
 				m.setPRReviewCmt(tlItem.item, 0)
+				m.prRevCurCmtNum = 0
 				m.activePane = prTLItemDetailView
+				m.refreshReviewComment()
+				cmds = append(cmds, m.fetchReviewThreadsIfNeeded())
 			}
 
 		case "4":
@@ -261,6 +312,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			if m.activePane == prListView {
+				if m.prGroupHeaderSelected() {
+					break
+				}
+
 				setTlCmd, ok := m.setTL()
 				if !ok {
 					m.message = "Could't get repo/pr details. Inform @dhth on github."
@@ -306,6 +361,12 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					cmds = append(cmds, openURLInBrowser(item.item.PullRequestReview.URL))
 				case tlItemMergedEvent:
 					cmds = append(cmds, openURLInBrowser(item.item.MergedEvent.URL))
+				case tlItemIssueComment:
+					cmds = append(cmds, openURLInBrowser(item.item.IssueComment.URL))
+				}
+			case checkRunDetailView:
+				if m.checkRunDetailsURL != nil {
+					cmds = append(cmds, openURLInBrowser(*m.checkRunDetailsURL))
 				}
 			}
 
@@ -314,14 +375,131 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 
-			pr, ok := m.prsList.SelectedItem().(*prResult)
+			prRes, ok := m.prsList.SelectedItem().(*prResult)
 			if !ok {
 				break
 			}
 
-			cmds = append(cmds, showDiff(pr.pr.Repository.Owner.Login,
-				pr.pr.Repository.Name,
-				pr.pr.Number))
+			cached, ok := m.prDiffCache[prRes.identifier]
+			if ok && cached.updatedAt.Equal(prRes.pr.UpdatedAt) {
+				m.showPRDiff(prRes, cached.diff)
+				break
+			}
+
+			m.message = "fetching diff..."
+			cmds = append(cmds, fetchPRDiff(m.prSourceForHost(getPRHost(prRes.pr)), prRes, false))
+
+		case "D":
+			if m.activePane != prListView && m.activePane != prTLListView && m.activePane != prDiffView {
+				break
+			}
+
+			prRes, ok := m.prsList.SelectedItem().(*prResult)
+			if !ok {
+				break
+			}
+
+			if !isGHAvailable() {
+				cached, ok := m.prDiffCache[prRes.identifier]
+				if ok && cached.updatedAt.Equal(prRes.pr.UpdatedAt) {
+					cmds = append(cmds, showDiffInPager(cached.diff, m.config.DiffPager))
+					break
+				}
+
+				m.message = "fetching diff..."
+				cmds = append(cmds, fetchPRDiff(m.prSourceForHost(getPRHost(prRes.pr)), prRes, true))
+				break
+			}
+
+			cmds = append(cmds, showDiff(getGHRepoArg(prRes.pr), prRes.pr.Number, m.config.DiffPager))
+
+		case "A", "X", "C":
+			if m.activePane != prListView && m.activePane != prDetailsView {
+				break
+			}
+
+			var event PullRequestReviewEvent
+			switch msg.String() {
+			case "A":
+				event = reviewApprove
+			case "X":
+				event = reviewRequestChanges
+			default:
+				event = reviewComment
+			}
+			cmds = append(cmds, m.startReview(event))
+
+		case "M":
+			if m.activePane != prListView && m.activePane != prDetailsView {
+				break
+			}
+
+			m.startMerge()
+
+		case "r":
+			if m.activePane != prTLItemDetailView {
+				break
+			}
+
+			cmds = append(cmds, m.startReplyToReviewThread())
+
+		case "R":
+			switch m.activePane {
+			case prTLItemDetailView:
+				cmds = append(cmds, m.toggleReviewThreadResolved())
+			case prDetailsView:
+				m.startChecksRerun()
+			}
+
+		case "f":
+			if m.activePane != prTLListView {
+				break
+			}
+
+			m.startTLFilter()
+
+		case "e":
+			if m.activePane != prDetailsView {
+				break
+			}
+
+			m.startMetadataEdit()
+
+		case "m":
+			if m.activePane != prDetailsView {
+				break
+			}
+
+			cmds = append(cmds, m.fetchMorePRDetails())
+
+		case "ctrl+a":
+			if m.activePane != prListView {
+				break
+			}
+
+			cmds = append(cmds, m.markAllPRsSeen())
+			m.message = "marked all PRs as read"
+
+		case "s":
+			if m.activePane != prListView {
+				break
+			}
+
+			m.cyclePRSort()
+
+		case "space":
+			if m.activePane != prListView {
+				break
+			}
+
+			m.togglePRGroup()
+
+		case "ctrl+g":
+			if m.activePane != prListView {
+				break
+			}
+
+			m.cyclePRGrouping()
 
 		case "ctrl+v":
 			if m.activePane == helpView {
@@ -332,9 +510,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				break
 			}
 
-			cmds = append(cmds, showPR(pr.pr.Repository.Owner.Login,
-				pr.pr.Repository.Name,
-				pr.pr.Number))
+			cmds = append(cmds, showPR(getGHRepoArg(pr.pr), pr.pr.Number))
 
 		case "g":
 			switch m.activePane {
@@ -342,6 +518,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.prTLItemDetailVP.GotoTop()
 			case prDetailsView:
 				m.prDetailsVP.GotoTop()
+			case prDiffView:
+				m.prDiffVP.GotoTop()
+			case checkRunDetailView:
+				m.checkRunVP.GotoTop()
 			case helpView:
 				m.helpVP.GotoTop()
 			}
@@ -351,16 +531,25 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.prTLItemDetailVP.GotoBottom()
 			case prDetailsView:
 				m.prDetailsVP.GotoBottom()
+			case prDiffView:
+				m.prDiffVP.GotoBottom()
+			case checkRunDetailView:
+				m.checkRunVP.GotoBottom()
 			case helpView:
 				m.helpVP.GotoBottom()
 			}
 
 		case "K", "[":
+			if m.activePane == prListView && msg.String() == "[" {
+				cmds = append(cmds, m.switchTab(-1))
+				break
+			}
+
 			if m.activePane != prDetailsView {
 				break
 			}
 
-			m.prsList.CursorUp()
+			m.movePRCursor(true)
 			prRes, ok := m.prsList.SelectedItem().(*prResult)
 			if !ok {
 				break
@@ -383,11 +572,16 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prDetailsCurrentSection = section
 
 		case "J", "]":
+			if m.activePane == prListView && msg.String() == "]" {
+				cmds = append(cmds, m.switchTab(1))
+				break
+			}
+
 			if m.activePane != prDetailsView {
 				break
 			}
 
-			m.prsList.CursorDown()
+			m.movePRCursor(false)
 			prRes, ok := m.prsList.SelectedItem().(*prResult)
 			if !ok {
 				break
@@ -444,13 +638,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prDetailsVP.GotoTop()
 			m.lastPane = m.activePane
 			m.activePane = prDetailsView
+			cmds = append(cmds, m.markPRSeen(prRes))
 
 		case "l", "n", "right":
-			if m.activePane != prDetailsView && m.activePane != prTLItemDetailView {
+			if m.activePane != prDetailsView && m.activePane != prTLItemDetailView && m.activePane != prDiffView {
 				break
 			}
 
 			switch m.activePane {
+			case prDiffView:
+				m.goToDiffFile(true)
+
 			case prDetailsView:
 				prRes, ok := m.prsList.SelectedItem().(*prResult)
 				if !ok {
@@ -549,11 +747,14 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 		case "h", "N", "left":
-			if m.activePane != prDetailsView && m.activePane != prTLItemDetailView {
+			if m.activePane != prDetailsView && m.activePane != prTLItemDetailView && m.activePane != prDiffView {
 				break
 			}
 
 			switch m.activePane {
+			case prDiffView:
+				m.goToDiffFile(false)
+
 			case prDetailsView:
 				prRes, ok := m.prsList.SelectedItem().(*prResult)
 				if !ok {
@@ -662,12 +863,22 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.repoList.SetWidth(msg.Width - w)
 		}
 
-		m.prsList.SetHeight(msg.Height - h - 2)
+		prsListHeight := msg.Height - h - 2
+		if m.showTabs() {
+			prsListHeight -= 2
+		}
+		m.prsList.SetHeight(prsListHeight)
 		m.prsList.SetWidth(msg.Width - w)
 
 		m.prTLList.SetHeight(msg.Height - h - 2)
 		m.prTLList.SetWidth(msg.Width - w)
 
+		m.metadataPicker.SetHeight(msg.Height - h - 2)
+		m.metadataPicker.SetWidth(msg.Width - w)
+
+		m.checkRunList.SetHeight(msg.Height - h - 2)
+		m.checkRunList.SetWidth(msg.Width - w)
+
 		if !m.prTLItemDetailVPReady {
 			m.prTLItemDetailVP = viewport.New(
 				viewport.WithWidth(msg.Width-2),
@@ -692,6 +903,36 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.prDetailsVP.SetHeight(msg.Height - 7)
 		}
 
+		m.composeTA.SetWidth(msg.Width - 4)
+		m.composeTA.SetHeight(msg.Height - 12)
+
+		if !m.prDiffVPReady {
+			m.prDiffVP = viewport.New(
+				viewport.WithWidth(msg.Width-2),
+				viewport.WithHeight(msg.Height-7),
+			)
+			m.prDiffVPReady = true
+			m.prDiffVP.KeyMap.HalfPageDown.SetKeys("ctrl+d")
+			// h/l are used to jump between files
+			m.prDiffVP.KeyMap.Left.SetEnabled(false)
+			m.prDiffVP.KeyMap.Right.SetEnabled(false)
+		} else {
+			m.prDiffVP.SetWidth(msg.Width - 2)
+			m.prDiffVP.SetHeight(msg.Height - 7)
+		}
+
+		if !m.checkRunVPReady {
+			m.checkRunVP = viewport.New(
+				viewport.WithWidth(msg.Width-2),
+				viewport.WithHeight(msg.Height-7),
+			)
+			m.checkRunVPReady = true
+			m.checkRunVP.KeyMap.HalfPageDown.SetKeys("ctrl+d")
+		} else {
+			m.checkRunVP.SetWidth(msg.Width - 2)
+			m.checkRunVP.SetHeight(msg.Height - 7)
+		}
+
 		vpWrap := min((msg.Width - 4), viewPortWrapUpperLimit)
 
 		m.mdRenderer, _ = utils.GetMarkDownRenderer(vpWrap)
@@ -717,162 +958,275 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.helpVP.SetHeight(msg.Height - 7)
 		}
 
-		prs := make([]list.Item, len(m.prCache))
 		for i := range m.prCache {
-			m.prCache[i].title = getPRTitle(m.prCache[i].pr)
+			m.prCache[i].title = getPRTitle(m.prCache[i].pr, m.prCache[i].unread)
 			m.prCache[i].description = getPRDesc(m.prCache[i].pr, m.mode, m.terminalDetails)
-			prs[i] = m.prCache[i]
 		}
-		m.prsList.SetItems(prs)
+		m.setPRListItems()
+		m.resizeInactiveTabs(msg.Width-w, prsListHeight)
 
 		if m.activePane == prTLListView {
-			m.setTL()
+			cmd, _ = m.setTL()
+			cmds = append(cmds, cmd)
 		}
 
 	case repoChosenMsg:
-		repoDetails := strings.Split(msg.repo, ":::")
-		if len(repoDetails) != 2 {
-			m.message = "Something went horribly wrong. Let @dhth know about this failure."
-		} else {
-			m.repoChosen = true
-			m.prsList.Title = fetchingPRsTitle
-			m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(fetchingColor))
-			m.repoOwner = repoDetails[0]
-			m.repoName = repoDetails[1]
-			m.activePane = prListView
-			m.prsList.ResetSelected()
-			m.prTLList.ResetSelected()
-			cmds = append(cmds, fetchPRSForRepo(m.ghClient, m.repoOwner, m.repoName, m.config.PRCount))
+		prSource, ok := m.hostSources[msg.repo.Host]
+		if !ok {
+			m.message = fmt.Sprintf("No Github client set up for host %s", msg.repo.Host)
+			break
 		}
+
+		m.repoChosen = true
+		m.prsList.Title = fetchingPRsTitle
+		m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(fetchingColor))
+		m.prSource = prSource
+		m.repoHost = msg.repo.Host
+		m.repoOwner = msg.repo.Owner
+		m.repoName = msg.repo.Name
+		m.activePane = prListView
+		m.prsPageInfo = pageInfo{}
+		m.awaitingPRs = true
+		m.fetchingMorePRs = false
+		m.prsGeneration++
+		m.prsFromCache = false
+		m.prsList.ResetSelected()
+		m.prTLList.ResetSelected()
+		cmds = append(cmds, loadCachedPRs(m.diskCache, m.prsHost(), m.prsQuery()))
+		cmds = append(cmds, fetchPRSForRepo(m.prSource, m.repoOwner, m.repoName, m.config.PRCount))
+	case cachedPRsLoadedMsg:
+		if msg.query != m.prsQuery() {
+			m.deferTabMsg(msg.query, msg)
+			break
+		}
+
+		if !m.awaitingPRs || msg.query != m.prsQuery() || len(msg.prs) == 0 {
+			break
+		}
+
+		m.setPRs(msg.prs)
+		m.prsFromCache = true
+		m.resetPRsListTitle()
+		m.prsList.Title = fmt.Sprintf("%s %s", m.prsList.Title, cachedPRsTitleSuffix)
+		m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(fetchingColor))
+		m.prsList.ResetSelected()
+
+		m.prefetchQueue = nil
+		cmds = append(cmds, m.enqueuePrefetch(msg.prs))
+
 	case prsFetchedMsg:
+		if msg.query != m.prsQuery() {
+			m.deferTabMsg(msg.query, msg)
+			break
+		}
+
+		m.awaitingPRs = false
+
 		if msg.err != nil {
 			m.message = msg.err.Error()
+			if m.prsFromCache {
+				m.resetPRsListTitle()
+				m.prsList.Title += " (cached)"
+				break
+			}
 			m.prsList.Title = "error"
 			break
 		}
 
-		prs := make([]list.Item, len(msg.prs))
-		prResults := make([]*prResult, len(msg.prs))
-		m.prDetailsCurSectionCache = make(map[string]uint)
+		var selected string
+		if prRes, ok := m.prsList.SelectedItem().(*prResult); ok && m.prsFromCache {
+			selected = prRes.identifier
+		}
 
-		for i, pr := range msg.prs {
-			prResults[i] = &prResult{
-				pr:          &pr,
-				title:       getPRTitle(&pr),
-				description: getPRDesc(&pr, m.mode, m.terminalDetails),
-				identifier:  fmt.Sprintf("%s/%s:%d", pr.Repository.Owner.Login, pr.Repository.Name, pr.Number),
-			}
-			prs[i] = prResults[i]
+		m.setPRs(msg.prs)
+		m.prsFromCache = false
+		m.prsPageInfo = msg.pageInfo
+		m.fetchingMorePRs = false
+		m.resetPRsListTitle()
+		m.prsList.ResetSelected()
+		selectPR(&m.prsList, selected)
+
+		m.prefetchQueue = nil
+		cmds = append(cmds, m.enqueuePrefetch(msg.prs))
+		cmds = append(cmds, saveQueryResultsToCache(m.diskCache, m.prsHost(), msg.query, msg.prs))
+
+	case notificationSentMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Couldn't send notification: %s", msg.err.Error())
 		}
 
-		m.prCache = prResults
-		m.prsList.SetItems(prs)
+	case droppedPRFetchedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Couldn't check on #%d: %s", msg.pr.Number, msg.err.Error())
+			break
+		}
 
-		switch m.mode {
-		case RepoMode:
-			m.prsList.Title = fmt.Sprintf("PRs (%s)", m.repoName)
-		case QueryMode:
-			m.prsList.Title = "Results"
+		if msg.details.State != prStateMerged {
+			break
 		}
 
-		m.prsList.ResetSelected()
-		m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(prListColor))
+		event := prEvent{event: PRMerged}
+		if msg.details.MergedBy != nil {
+			event.actor = msg.details.MergedBy.Login
+		}
+		cmds = append(cmds, m.notifyPREvents(&msg.pr, []prEvent{event}))
 
-		for _, pr := range msg.prs {
-			cmds = append(cmds, fetchPRTLItems(m.ghClient,
-				pr.Repository.Owner.Login,
-				pr.Repository.Name,
-				pr.Number,
-				100,
-				false,
-			))
-			cmds = append(cmds, fetchPRMetadata(m.ghClient,
-				pr.Repository.Owner.Login,
-				pr.Repository.Name,
-				pr.Number,
-			))
-		}
-
-	case reviewPRsFetchedMsg:
+	case seenStateSavedMsg:
 		if msg.err != nil {
-			m.message = msg.err.Error()
+			m.message = fmt.Sprintf("Couldn't save read state: %s", msg.err.Error())
+		}
+		cmds = append(cmds, m.seen.saved())
+
+	case refreshTickMsg:
+		cmds = append(cmds, scheduleRefresh(m.config.RefreshInterval))
+		cmds = append(cmds, m.refreshPRsIfIdle())
+
+	case prsRefreshedMsg:
+		if msg.query != m.prsQuery() {
+			m.deferTabMsg(msg.query, msg)
 			break
 		}
 
-		prs := make([]list.Item, len(msg.prs))
-		prResults := make([]*prResult, len(msg.prs))
-		m.prDetailsCurSectionCache = make(map[string]uint)
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Couldn't refresh PRs: %s", msg.err.Error())
+			break
+		}
 
-		for i, pr := range msg.prs {
-			prResults[i] = &prResult{
-				pr:          &pr,
-				title:       getPRTitle(&pr),
-				description: getPRDesc(&pr, m.mode, m.terminalDetails),
-				identifier:  fmt.Sprintf("%s/%s:%d", pr.Repository.Owner.Login, pr.Repository.Name, pr.Number),
-			}
-			prs[i] = prResults[i]
+		// a manual reload, or a fetch for the next page, superseded this
+		if m.awaitingPRs || m.fetchingMorePRs {
+			break
 		}
 
-		m.prCache = prResults
-		m.prsList.SetItems(prs)
-		m.prsList.ResetSelected()
-		m.prsList.Title = "Open PRs requesting your review"
-		m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(prListColor))
+		prevPRs := m.prCache
+		updates := m.setRefreshedPRs(msg.prs)
+		m.prsPageInfo = msg.pageInfo
+		cmds = append(cmds, m.checkDroppedPRs(prevPRs))
 
-		if len(msg.prs) > 0 {
-			for _, pr := range msg.prs {
-				cmds = append(cmds, fetchPRTLItems(m.ghClient, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number, 100, false))
-				cmds = append(cmds, fetchPRMetadata(m.ghClient,
-					pr.Repository.Owner.Login,
-					pr.Repository.Name,
-					pr.Number,
-				))
+		changed := make([]pr, len(updates))
+		for i, u := range updates {
+			changed[i] = *u.prRes.pr
+			if u.changes&prChangeChecksFailed != 0 {
+				cmds = append(cmds, m.notifyPREvents(u.prRes.pr, []prEvent{{event: PRChecksFailed}}))
 			}
 		}
-	case authoredPRsFetchedMsg:
+
+		cmds = append(cmds, m.enqueuePrefetch(changed))
+		cmds = append(cmds, saveQueryResultsToCache(m.diskCache, m.prsHost(), msg.query, msg.prs))
+
+	case morePRsFetchedMsg:
+		if msg.query != m.prsQuery() {
+			m.deferTabMsg(msg.query, msg)
+			break
+		}
+
+		// the list was reloaded while this page was being fetched
+		if msg.generation != m.prsGeneration {
+			break
+		}
+
+		m.fetchingMorePRs = false
+		m.resetPRsListTitle()
+
 		if msg.err != nil {
 			m.message = msg.err.Error()
 			break
 		}
 
-		prs := make([]list.Item, len(msg.prs))
-		prResults := make([]*prResult, len(msg.prs))
-		m.prDetailsCurSectionCache = make(map[string]uint)
+		m.prsPageInfo = msg.pageInfo
 
-		for i, pr := range msg.prs {
-			prResults[i] = &prResult{
-				pr:          &pr,
-				title:       getPRTitle(&pr),
-				description: getPRDesc(&pr, m.mode, m.terminalDetails),
-				identifier:  fmt.Sprintf("%s/%s:%d", pr.Repository.Owner.Login, pr.Repository.Name, pr.Number),
-			}
-			prs[i] = prResults[i]
+		for _, pr := range msg.prs {
+			m.prCache = append(m.prCache, m.newPRResult(pr))
 		}
+		cmds = append(cmds, m.enqueuePrefetch(msg.prs))
+		m.setPRListItems()
 
-		m.prCache = prResults
-		m.prsList.SetItems(prs)
-		m.prsList.Title = "Open PRs authored by you"
-		m.prsList.ResetSelected()
-		m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(prListColor))
+	case prsPrefetchedMsg:
+		m.prefetchInFlight--
 
-		if len(msg.prs) > 0 {
-			for _, pr := range msg.prs {
-				cmds = append(cmds, fetchPRTLItems(m.ghClient, pr.Repository.Owner.Login, pr.Repository.Name, pr.Number, 100, false))
-				cmds = append(cmds, fetchPRMetadata(m.ghClient,
-					pr.Repository.Owner.Login,
-					pr.Repository.Name,
-					pr.Number,
-				))
+		for i, p := range msg.prs {
+			identifier := p.identifier()
+			details := msg.data[i].details
+			if prevDetails, ok := m.prDetailsCache[identifier]; ok {
+				details = keepPagedInPRDetails(prevDetails, details)
 			}
+			m.prDetailsCache[identifier] = details
+
+			tlItems, tlPageInfo := newPRTLItemResults(msg.data[i].tlItems), msg.data[i].tlPageInfo
+			// only PRs whose timeline was known already can have new events
+			if prevTLItems, ok := m.prTLCache[identifier]; ok {
+				cmds = append(cmds, m.notifyNewTLEvents(identifier, prevTLItems, msg.data[i].tlItems))
+				tlItems, tlPageInfo = keepEarlierTLItems(prevTLItems, m.prTLPageInfoCache[identifier], msg.data[i].tlItems, tlPageInfo)
+			}
+
+			m.prTLCache[identifier] = tlItems
+			m.prTLPageInfoCache[identifier] = tlPageInfo
 		}
 
+		if msg.err != nil {
+			m.message = msg.err.Error()
+		}
+
+		for _, p := range msg.prs {
+			m.updateUnreadForPR(p.identifier())
+		}
+
+		cmds = append(cmds, m.dispatchPrefetch())
+
 	case prMetadataFetchedMsg:
 		if msg.err != nil {
 			m.message = msg.err.Error()
 			break
 		}
 
-		m.prDetailsCache[fmt.Sprintf("%s/%s:%d", msg.repoOwner, msg.repoName, msg.prNumber)] = msg.metadata
+		m.prDetailsCache[msg.identifier] = msg.metadata
+
+	case morePRDetailsFetchedMsg:
+		m.fetchingMorePRDetails = false
+
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error fetching %s: %s", msg.section.pagedItemsLabel(), msg.err.Error())
+			break
+		}
+
+		m.addMorePRDetails(msg)
+
+	case checksRerunMsg:
+		if msg.numRerun > 0 {
+			m.checksPolls[msg.identifier] = &checksPoll{host: msg.host}
+			cmds = append(cmds, pollChecks(msg.identifier, msg.repoOwner, msg.repoName, msg.prNumber))
+			m.refreshShownPRDetails(msg.identifier)
+		}
+
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error re-running checks (%d re-run): %s", msg.numRerun, msg.err.Error())
+			break
+		}
+
+		m.message = fmt.Sprintf("re-running %d failed check suite(s) on #%d", msg.numRerun, msg.prNumber)
+
+	case checksPollMsg:
+		poll, ok := m.checksPolls[msg.identifier]
+		if !ok {
+			break
+		}
+
+		cmds = append(cmds, fetchPolledChecks(m.prSourceForHost(poll.host), msg.identifier, msg.repoOwner, msg.repoName, msg.prNumber))
+
+	case checksPolledMsg:
+		if _, ok := m.checksPolls[msg.identifier]; !ok {
+			break
+		}
+
+		if msg.err != nil {
+			delete(m.checksPolls, msg.identifier)
+			m.message = fmt.Sprintf("stopped following checks on #%d: %s", msg.prNumber, msg.err.Error())
+			m.refreshShownPRDetails(msg.identifier)
+			break
+		}
+
+		m.prDetailsCache[msg.identifier] = msg.details
+		cmds = append(cmds, m.trackPolledChecks(msg.identifier, msg.details))
+		m.refreshShownPRDetails(msg.identifier)
 
 	case prTLFetchedMsg:
 		if msg.err != nil {
@@ -880,37 +1234,164 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			break
 		}
 
-		tlItemsResult := make([]*prTLItemResult, len(msg.prTLItems))
+		tlItemsResult := newPRTLItemResults(msg.prTLItems)
+		m.prTLCache[msg.identifier] = tlItemsResult
+		m.prTLPageInfoCache[msg.identifier] = msg.pageInfo
+		m.updateUnreadForPR(msg.identifier)
+
+		if msg.setItems {
+			m.setPRTLListItems(msg.identifier, msg.prNumber)
+			m.activePane = prTLListView
+		}
 
-		for i, item := range msg.prTLItems {
-			tlItemsResult[i] = &prTLItemResult{
-				item:        &item,
-				title:       getPRTLItemTitle(&item),
-				description: getPRTLItemDesc(&item),
+		m.prTLList.ResetSelected()
+
+	case earlierPRTLItemsFetchedMsg:
+		m.fetchingEarlierTLItems = false
+
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error fetching earlier timeline items: %s", msg.err.Error())
+			if prRes, ok := m.prsList.SelectedItem().(*prResult); ok && prRes.identifier == msg.identifier {
+				m.setPRTLListTitle(msg.identifier, prRes.pr.Number)
 			}
+			break
 		}
-		m.prTLCache[fmt.Sprintf("%s/%s:%d", msg.repoOwner, msg.repoName, msg.prNumber)] = tlItemsResult
 
-		if msg.setItems {
-			prTLItems := make([]list.Item, len(msg.prTLItems))
-			for i, result := range tlItemsResult {
-				prTLItems[i] = result
+		m.addEarlierTLItems(msg)
+
+	case reviewSubmittedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error submitting review: %s", msg.err.Error())
+			break
+		}
+
+		m.message = fmt.Sprintf("Review submitted on #%d (%s)", msg.prNumber, strings.ToLower(msg.state))
+		if m.activePane == composeView {
+			m.stopComposing()
+		}
+		cmds = append(cmds, fetchPRTLItems(m.prSourceForHost(msg.host), msg.identifier, msg.repoOwner, msg.repoName, msg.prNumber, 100, false))
+
+	case prMergedMsg:
+		if msg.err != nil {
+			if msg.auto {
+				m.message = fmt.Sprintf("Error enabling auto-merge for #%d: %s", msg.prNumber, msg.err.Error())
+			} else {
+				m.message = fmt.Sprintf("Error merging #%d: %s", msg.prNumber, msg.err.Error())
 			}
-			m.prTLList.SetItems(prTLItems)
-			m.prTLList.Title = fmt.Sprintf("PR #%d Timeline", msg.prNumber)
-			m.prTLList.Styles.Title = m.prTLList.Styles.Title.Background(lipgloss.Color(prTLListColor))
-			m.activePane = prTLListView
+			break
 		}
 
-		m.prTLList.ResetSelected()
+		if msg.auto {
+			m.message = fmt.Sprintf("Auto-merge (%s) enabled for #%d", msg.method.label(), msg.prNumber)
+			break
+		}
+
+		m.message = fmt.Sprintf("Merged #%d (%s)", msg.prNumber, msg.method.label())
+		m.markPRMerged(msg.identifier)
+
+	case repoMetadataOptionsFetchedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error fetching %s: %s", msg.kind.label(), msg.err.Error())
+			break
+		}
+
+		m.repoMetadataCache[fmt.Sprintf("%s/%s/%s", msg.host, msg.repoOwner, msg.repoName)] = msg.options
+
+		prRes, ok := m.prsList.SelectedItem().(*prResult)
+		if !ok || prRes.identifier != msg.identifier || m.activePane != prDetailsView {
+			break
+		}
+		m.showMetadataPicker(prRes, msg.kind, msg.options)
+
+	case prMetadataEditedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Couldn't update %s: %s", msg.edit.kind.label(), msg.err.Error())
+			details, ok := m.prDetailsCache[msg.identifier]
+			if !ok {
+				break
+			}
+			m.prDetailsCache[msg.identifier] = applyMetadataEdit(details, msg.revert)
+			cmds = append(cmds, m.refreshMetadataViews(msg.identifier))
+			break
+		}
+
+		m.message = fmt.Sprintf("%s updated", msg.edit.kind.label())
+
+	case reviewThreadsFetchedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error fetching review threads: %s", msg.err.Error())
+			break
+		}
+
+		m.reviewThreadsCache[msg.identifier] = msg.threads
+		m.refreshReviewComment()
+
+	case reviewThreadUpdatedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Couldn't %s thread: %s", msg.action, msg.err.Error())
+			break
+		}
+
+		switch msg.action {
+		case "reply":
+			m.message = "Reply added"
+		default:
+			m.message = fmt.Sprintf("Thread %sd", msg.action)
+		}
+		if m.activePane == composeView {
+			m.stopComposing()
+		}
+		cmds = append(cmds, fetchReviewThreads(m.prSourceForHost(msg.host), msg.identifier, msg.repoOwner, msg.repoName, msg.prNumber))
 
 	case urlOpenedinBrowserMsg:
 		if msg.err != nil {
 			m.message = fmt.Sprintf("Error opening url: %s", msg.err.Error())
 		}
+	case prDiffFetchedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error fetching diff: %s", msg.err.Error())
+			break
+		}
+
+		m.prDiffCache[msg.identifier] = prDiffCacheEntry{diff: msg.diff, updatedAt: msg.updatedAt}
+
+		if msg.showInPager {
+			cmds = append(cmds, showDiffInPager(msg.diff, m.config.DiffPager))
+			break
+		}
+
+		// the diff is only shown if the user is still on the same PR
+		prRes, ok := m.prsList.SelectedItem().(*prResult)
+		if !ok || prRes.identifier != msg.identifier {
+			break
+		}
+		if m.activePane != prListView && m.activePane != prTLListView {
+			break
+		}
+
+		m.showPRDiff(prRes, msg.diff)
+	case checkRunFetchedMsg:
+		if msg.err != nil {
+			m.message = fmt.Sprintf("Error fetching check run: %s", msg.err.Error())
+			break
+		}
+
+		// check runs that are still going will have more to show later on,
+		// and a log that couldn't be fetched may be fetched the next time
+		if msg.details.run.Status == checkStatusStateCompleted && msg.details.logErr == nil {
+			m.checkRunCache[msg.checkRunID] = msg.details
+		}
+
+		// the check run is only shown if the user is still on it
+		item, ok := m.checkRunList.SelectedItem().(checkRunItem)
+		if !ok || item.id != msg.checkRunID || m.activePane != checkRunListView {
+			break
+		}
+
+		m.showCheckRun(msg.details)
 	case prDiffDoneMsg:
 		if msg.err != nil {
-			m.message = fmt.Sprintf("Error opening diff (is gh installed?): %s", msg.err.Error())
+			m.message = fmt.Sprintf("Error opening diff: %s", msg.err.Error())
 		}
 	case prViewDoneMsg:
 		if msg.err != nil {
@@ -922,23 +1403,48 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	case prListView:
 		m.prsList, cmd = m.prsList.Update(msg)
 		cmds = append(cmds, cmd)
+		cmds = append(cmds, m.fetchMorePRsIfAtEnd())
 	case prTLListView:
 		m.prTLList, cmd = m.prTLList.Update(msg)
 		cmds = append(cmds, cmd)
+		if keyMsg, ok := msg.(tea.KeyPressMsg); ok {
+			cmds = append(cmds, m.fetchEarlierTLItemsIfAtStart(keyMsg))
+		}
 	case prDetailsView:
 		m.prDetailsVP, cmd = m.prDetailsVP.Update(msg)
 		cmds = append(cmds, cmd)
 	case prTLItemDetailView:
 		m.prTLItemDetailVP, cmd = m.prTLItemDetailVP.Update(msg)
 		cmds = append(cmds, cmd)
+	case prDiffView:
+		m.prDiffVP, cmd = m.prDiffVP.Update(msg)
+		cmds = append(cmds, cmd)
+	case checkRunListView:
+		m.checkRunList, cmd = m.checkRunList.Update(msg)
+		cmds = append(cmds, cmd)
+	case checkRunDetailView:
+		m.checkRunVP, cmd = m.checkRunVP.Update(msg)
+		cmds = append(cmds, cmd)
+	case composeView:
+		m.composeTA, cmd = m.composeTA.Update(msg)
+		cmds = append(cmds, cmd)
+	case metadataPickerView:
+		m.metadataPicker, cmd = m.metadataPicker.Update(msg)
+		cmds = append(cmds, cmd)
 	case repoListView:
+		prevIndex := m.repoList.Index()
 		m.repoList, cmd = m.repoList.Update(msg)
+		m.skipRepoGroupHeader(prevIndex)
 		cmds = append(cmds, cmd)
 	case helpView:
 		m.helpVP, cmd = m.helpVP.Update(msg)
 		cmds = append(cmds, cmd)
 	}
 
+	if rlr, ok := m.prSource.(rateLimitReporter); ok {
+		m.rateLimit = rlr.getRateLimit()
+	}
+
 	return m, tea.Batch(cmds...)
 }
 
@@ -956,29 +1462,129 @@ func (m *Model) setTL() (tea.Cmd, bool) {
 	repoName = prRes.pr.Repository.Name
 	prNumber = prRes.pr.Number
 
+	prRes.changes = 0
+	seenCmd := m.markPRSeen(prRes)
+
 	tlFromCache, ok := m.prTLCache[prRes.identifier]
 	if !ok {
-		cmd = fetchPRTLItems(m.ghClient, repoOwner, repoName, prNumber, 100, true)
-		return cmd, true
+		cmd = fetchPRTLItems(m.prSourceForHost(getPRHost(prRes.pr)), prRes.identifier, repoOwner, repoName, prNumber, 100, true)
+		return tea.Batch(cmd, seenCmd), true
 	}
 
-	tlItems := make([]list.Item, len(tlFromCache))
-
 	// this list always get rerendered as it seems to be preferrable over recomputing the string rep of every item in
 	// every list in m.prTLCache when the terminal window is resized
-	for i, result := range tlFromCache {
+	for _, result := range tlFromCache {
 		title := getPRTLItemTitle(result.item)
 		description := getPRTLItemDesc(result.item)
 
 		result.title = title
 		result.description = description
-
-		tlItems[i] = result
 	}
 
-	m.prTLList.SetItems(tlItems)
-	m.prTLList.Title = fmt.Sprintf("PR #%d Timeline", prNumber)
+	m.setPRTLListItems(prRes.identifier, prNumber)
 	m.activePane = prTLListView
 
-	return nil, true
+	return seenCmd, true
+}
+
+// setPRs replaces the PRs shown in the PR list.
+func (m *Model) setPRs(prs []pr) {
+	prResults := make([]*prResult, len(prs))
+	m.prDetailsCurSectionCache = make(map[string]uint)
+
+	for i, pr := range prs {
+		prResults[i] = m.newPRResult(pr)
+	}
+
+	m.prCache = prResults
+	m.setPRListItems()
+}
+
+func (m *Model) newPRResult(pr pr) *prResult {
+	prRes := &prResult{
+		pr:          &pr,
+		description: getPRDesc(&pr, m.mode, m.terminalDetails),
+		identifier:  getPRIdentifier(getPRHost(&pr), pr.Repository.Owner.Login, pr.Repository.Name, pr.Number),
+	}
+	m.updateUnread(prRes)
+
+	return prRes
+}
+
+// getPRIdentifier returns the key a PR's data is cached under; repos on
+// different hosts can share a name, so the host is part of it.
+func getPRIdentifier(host, repoOwner, repoName string, prNumber int) string {
+	return fmt.Sprintf("%s/%s/%s:%d", host, repoOwner, repoName, prNumber)
+}
+
+// prsHost returns the host the PRs being shown are fetched from.
+func (m Model) prsHost() string {
+	if m.mode == RepoMode && m.repoHost != "" {
+		return m.repoHost
+	}
+	return m.config.Host
+}
+
+// prSourceForHost returns the source for PRs on host. That's not necessarily
+// the one the PRs being shown are fetched from, as PRs fetched before a repo
+// on another host was chosen can still be acted on.
+func (m Model) prSourceForHost(host string) prDataSource {
+	if host == m.config.Host {
+		host = ""
+	}
+
+	prSource, ok := m.hostSources[host]
+	if !ok {
+		return m.prSource
+	}
+	return prSource
+}
+
+func (m Model) prsQuery() string {
+	if m.mode == RepoMode {
+		return getRepoPRsQuery(m.repoOwner, m.repoName)
+	}
+	return m.tabs[m.activeTab].query
+}
+
+func (m *Model) resetPRsListTitle() {
+	switch m.mode {
+	case RepoMode:
+		m.prsList.Title = fmt.Sprintf("PRs (%s)", m.repoName)
+	case QueryMode:
+		m.prsList.Title = "Results"
+		if name := m.tabs[m.activeTab].name; name != "" {
+			m.prsList.Title = name
+		}
+	}
+	var order []string
+	if m.prSort != sortByQuery {
+		order = append(order, "by "+m.prSort.label())
+	}
+	if m.prGrouping != noGrouping {
+		order = append(order, "grouped by "+m.prGrouping.label())
+	}
+	if len(order) > 0 {
+		m.prsList.Title += fmt.Sprintf(" (%s)", strings.Join(order, ", "))
+	}
+	m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(prListColor))
+}
+
+// fetchMorePRsIfAtEnd returns a command to fetch the next page of search results if
+// the cursor is on the last PR in the list, and more results are available.
+func (m *Model) fetchMorePRsIfAtEnd() tea.Cmd {
+	if m.fetchingMorePRs || !m.prsPageInfo.HasNextPage || m.prsPageInfo.EndCursor == nil {
+		return nil
+	}
+
+	numItems := len(m.prsList.VisibleItems())
+	if numItems == 0 || m.prsList.Index() < numItems-1 {
+		return nil
+	}
+
+	m.fetchingMorePRs = true
+	m.prsList.Title = fetchingMorePRsTitle
+	m.prsList.Styles.Title = m.prsList.Styles.Title.Background(lipgloss.Color(fetchingColor))
+
+	return fetchMorePRs(m.prSource, m.prsQuery(), m.prsGeneration, m.config.PRCount, m.prsPageInfo.EndCursor)
 }
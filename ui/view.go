@@ -20,12 +20,20 @@ func (m Model) View() tea.View {
 	if m.message != "" {
 		statusBar = RightPadTrim(m.message, m.terminalDetails.width)
 	}
+	if m.confirmation != nil {
+		statusBar = confirmationStyle.Render(RightPadTrim(fmt.Sprintf("%s (%s)", m.confirmation.prompt, m.confirmation.options), m.terminalDetails.width))
+	}
 
 	switch m.activePane {
 	case prListView:
 		content = listStyle.Render(m.prsList.View())
+		if m.showTabs() {
+			content = lipgloss.JoinVertical(lipgloss.Left, m.getTabBar(), content)
+		}
 	case prTLListView:
 		content = listStyle.Render(m.prTLList.View())
+	case metadataPickerView:
+		content = listStyle.Render(m.metadataPicker.View())
 	case repoListView:
 		content = listStyle.Render(m.repoList.View())
 	case prDetailsView:
@@ -36,6 +44,30 @@ func (m Model) View() tea.View {
 				prDetailsTitleStyle.Render(m.prDetailsTitle),
 				m.prDetailsVP.View()))
 		}
+	case prDiffView:
+		if !m.prDiffVPReady {
+			content = vpNotReadyMsg
+		} else {
+			content = viewPortStyle.Render(fmt.Sprintf("  %s\n\n%s\n",
+				prDiffTitleStyle.Render(m.prDiffTitle),
+				m.prDiffVP.View()))
+		}
+	case checkRunListView:
+		content = listStyle.Render(m.checkRunList.View())
+	case checkRunDetailView:
+		if !m.checkRunVPReady {
+			content = vpNotReadyMsg
+		} else {
+			content = viewPortStyle.Render(fmt.Sprintf("  %s\n\n%s\n",
+				checkRunTitleStyle.Render(m.checkRunTitle),
+				m.checkRunVP.View()))
+		}
+	case composeView:
+		content = viewPortStyle.Render(fmt.Sprintf("  %s\n\n%s\n\n%s",
+			composeTitleStyle.Render(m.getComposeTitle()),
+			composeTAStyle.Render(m.composeTA.View()),
+			composeHintStyle.Render("ctrl+s: submit, esc: discard"),
+		))
 	case prTLItemDetailView:
 		var prRevCmtsVP string
 		if !m.prTLItemDetailVPReady {
@@ -63,9 +95,15 @@ func (m Model) View() tea.View {
 		helpMsg = helpMsgStyle.Render("Press ? for help")
 	}
 
-	footerStr := fmt.Sprintf("%s%s",
+	var rateLimitMsg string
+	if m.rateLimit != nil {
+		rateLimitMsg = rateLimitStyle(m.rateLimit.isLow()).Render(fmt.Sprintf("api budget: %d/%d", m.rateLimit.Remaining, m.rateLimit.Limit))
+	}
+
+	footerStr := fmt.Sprintf("%s%s%s",
 		toolNameStyle.Render("prs"),
 		helpMsg,
+		rateLimitMsg,
 	)
 	footer = footerStyle.Render(footerStr)
 